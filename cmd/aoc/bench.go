@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/timing"
+)
+
+func benchCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "only benchmark this day")
+	part := fs.Int("part", 0, "part to benchmark (1 or 2, default both)")
+	name := fs.String("input", "input", "input file name inside each dayNN directory")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	count := fs.Int("count", 5, "number of measurements per part")
+	benchTime := fs.Duration("benchtime", 200*time.Millisecond, "how long each measurement repeats the part for")
+	historyFile := fs.String("history", "", "benchmark history (default <root>/"+timing.DefaultHistoryFile+")")
+	save := fs.Bool("save", false, "store the results under the current commit")
+	compare := fs.Bool("compare", false, "compare the results with an earlier commit and fail on regressions")
+	base := fs.String("base", "", "commit to compare with (default the latest other saved commit)")
+	threshold := fs.Float64("threshold", 10, "percentage a part may get slower or allocate more before it is a regression")
+	commit := fs.String("commit", "", "commit to save the results under (default git HEAD)")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New("--count must be at least 1")
+	}
+	if *historyFile == "" {
+		*historyFile = filepath.Join(*root, timing.DefaultHistoryFile)
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []solver.Day{d}
+	}
+	days, err := sets.configure(days)
+	if err != nil {
+		return err
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	record := timing.Record{Commit: *commit, Date: time.Now().UTC()}
+	if record.Commit == "" && (*save || *compare) {
+		var err error
+		if record.Commit, record.Dirty, err = gitCommit(*root); err != nil {
+			return err
+		}
+	}
+
+	history, err := timing.LoadHistory(*historyFile)
+	if err != nil {
+		return err
+	}
+	var baseline timing.Record
+	if *compare {
+		var ok bool
+		if *base != "" {
+			baseline, ok = history.Find(*base)
+		} else {
+			baseline, ok = history.Latest(record.Commit)
+		}
+		if !ok {
+			return fmt.Errorf("no saved benchmark to compare with in %s", *historyFile)
+		}
+	}
+
+	// Repeating the whole set rather than each part in turn spreads
+	// machine noise over all parts.
+	for range *count {
+		results, err := measureDays(days, parts, *root, *name, *benchTime)
+		if err != nil {
+			return err
+		}
+		addRuns(&record, results)
+	}
+
+	regressions := 0
+	if *compare {
+		fmt.Fprintf(stdout, "base %s (%s), new %s\n", short(baseline.Commit), baseline.Date.Format(time.DateOnly), short(record.Commit))
+		regressions = printComparison(stdout, baseline, record, *threshold/100)
+	} else {
+		printBenches(stdout, record)
+	}
+
+	if *save {
+		history.Add(record)
+		if err := history.Save(*historyFile); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "saved as %s in %s\n", short(record.Commit), *historyFile)
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%d regression(s) beyond %g%%", regressions, *threshold)
+	}
+	return nil
+}
+
+// addRuns appends one measurement of every part to the record.
+func addRuns(record *timing.Record, results []timing.Result) {
+	for _, r := range results {
+		found := false
+		for i, b := range record.Benches {
+			if b.Day == r.Day && b.Part == r.Part && b.Input == r.Input {
+				record.Benches[i].Runs = append(b.Runs, r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			record.Benches = append(record.Benches, timing.Bench{Day: r.Day, Part: r.Part, Input: r.Input, Runs: []timing.Result{r}})
+		}
+	}
+}
+
+// printBenches writes the median and variation of every part.
+func printBenches(w io.Writer, record timing.Record) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tTIME/OP\tALLOCS/OP\tN")
+	for _, b := range record.Benches {
+		ns := timing.Summarize(b.NsPerOp())
+		if ns.N == 0 {
+			fmt.Fprintf(tw, "%02d\t%d\tERROR: %s\t\t\n", b.Day, b.Part, b.Runs[0].Error)
+			continue
+		}
+		allocs := timing.Summarize(b.AllocsPerOp())
+		fmt.Fprintf(tw, "%02d\t%d\t%s\t%s\t%d\n", b.Day, b.Part, formatTime(ns), formatCount(allocs), ns.N)
+	}
+	tw.Flush()
+}
+
+// printComparison writes a benchstat-like table of time and allocations
+// per part and returns how many got worse by more than threshold with a
+// significant p-value.
+func printComparison(w io.Writer, base, record timing.Record, threshold float64) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tMETRIC\tBASE\tNEW\tDELTA")
+	regressions := 0
+	for _, b := range record.Benches {
+		old, ok := base.Bench(b.Day, b.Part, b.Input)
+		if !ok {
+			continue
+		}
+		metrics := []struct {
+			name   string
+			base   []float64
+			new    []float64
+			format func(timing.Summary) string
+		}{
+			{"time/op", old.NsPerOp(), b.NsPerOp(), formatTime},
+			{"allocs/op", old.AllocsPerOp(), b.AllocsPerOp(), formatCount},
+		}
+		for _, m := range metrics {
+			d := timing.Compare(m.base, m.new)
+			delta := "~"
+			if d.Significant() {
+				delta = fmt.Sprintf("%+.1f%%", d.Change*100)
+			}
+			delta += fmt.Sprintf(" (p=%.3f n=%d+%d)", d.P, d.Base.N, d.New.N)
+			if d.Significant() && d.Change > threshold {
+				delta += " REGRESSION"
+				regressions++
+			}
+			fmt.Fprintf(tw, "%02d\t%d\t%s\t%s\t%s\t%s\n", b.Day, b.Part, m.name, m.format(d.Base), m.format(d.New), delta)
+		}
+	}
+	tw.Flush()
+	return regressions
+}
+
+func formatTime(s timing.Summary) string {
+	return fmt.Sprintf("%v ±%.0f%%", roundDuration(time.Duration(s.Median)), s.Variation*100)
+}
+
+func formatCount(s timing.Summary) string {
+	return fmt.Sprintf("%.0f ±%.0f%%", s.Median, s.Variation*100)
+}
+
+// gitCommit returns the HEAD commit of the repository at root and whether
+// the working tree has uncommitted changes.
+func gitCommit(root string) (string, bool, error) {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", false, fmt.Errorf("finding the current commit (use --commit): %w", err)
+	}
+	status, err := exec.Command("git", "-C", root, "status", "--porcelain").Output()
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(string(out)), len(status) > 0, nil
+}
+
+// short abbreviates a commit hash like git log --oneline.
+func short(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
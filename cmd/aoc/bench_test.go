@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/timing"
+)
+
+func TestBenchSaveAndCompare(t *testing.T) {
+	history := filepath.Join(t.TempDir(), timing.DefaultHistoryFile)
+	common := []string{"--day", "7", "--part", "1", "--input", "input_test", "--root", "../..",
+		"--count", "5", "--benchtime", "1ms", "--history", history}
+
+	var stdout, stderr bytes.Buffer
+	if err := benchCmd(append(common, "--save", "--commit", "aaaaaaa111"), &stdout, &stderr); err != nil {
+		t.Fatalf("Error saving: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "saved as aaaaaaa in") {
+		t.Errorf("Expected the save to be reported, got:\n%s", stdout.String())
+	}
+
+	h, err := timing.LoadHistory(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 1 || len(h[0].Benches) != 1 || len(h[0].Benches[0].Runs) != 5 {
+		t.Fatalf("Expected one bench with 5 runs, got %+v", h)
+	}
+
+	// Pretend the saved commit was a thousand times faster.
+	for i := range h[0].Benches[0].Runs {
+		h[0].Benches[0].Runs[i].NsPerOp /= 1000
+	}
+	if err := h.Save(history); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	err = benchCmd(append(common, "--compare", "--commit", "bbbbbbb222"), &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "1 regression(s) beyond 10%") {
+		t.Errorf("Expected one regression, got %v", err)
+	}
+	for _, want := range []string{"base aaaaaaa", "time/op", "REGRESSION", "allocs/op", "~ (p=1.000 n=5+5)"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+
+	if err := benchCmd(append(common, "--compare", "--base", "ccc", "--commit", "x"), &stdout, &stderr); err == nil {
+		t.Error("Expected an error for an unknown base commit")
+	}
+}
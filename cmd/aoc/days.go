@@ -0,0 +1,14 @@
+package main
+
+// Every day registers itself with the solver registry from its init function.
+import (
+	_ "github.com/abtris/aoc2025/day01"
+	_ "github.com/abtris/aoc2025/day02"
+	_ "github.com/abtris/aoc2025/day03"
+	_ "github.com/abtris/aoc2025/day04"
+	_ "github.com/abtris/aoc2025/day05"
+	_ "github.com/abtris/aoc2025/day06"
+	_ "github.com/abtris/aoc2025/day07"
+	_ "github.com/abtris/aoc2025/day08"
+	_ "github.com/abtris/aoc2025/day09"
+)
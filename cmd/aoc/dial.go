@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abtris/aoc2025/day01"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// marksFlag is a comma separated list of dial marks.
+type marksFlag []int
+
+func (m *marksFlag) String() string {
+	if m == nil {
+		return ""
+	}
+	s := make([]string, len(*m))
+	for i, n := range *m {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+func (m *marksFlag) Set(s string) error {
+	*m = nil
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return fmt.Errorf("expected comma separated marks, got %q", s)
+		}
+		*m = append(*m, n)
+	}
+	return nil
+}
+
+// dialSolver returns the day 1 solver configured by --set and --targets.
+func dialSolver(sets *paramFlag, targets marksFlag) (day01.Solver, error) {
+	d, ok := solver.Lookup(1)
+	if !ok {
+		return day01.Solver{}, fmt.Errorf("day 1 is not registered")
+	}
+	days, err := sets.configure([]solver.Day{d})
+	if err != nil {
+		return day01.Solver{}, err
+	}
+	s := days[0].Solver.(day01.Solver)
+	s.Targets = targets
+	return s, nil
+}
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// replayDial draws the dial after each step, clearing the screen in
+// between, until the steps run out or ctx ends.
+func replayDial(ctx context.Context, w io.Writer, d *day01.Dial, steps []day01.Step, delay time.Duration) error {
+	crossings := solver.Int(0)
+	stops := 0
+	for _, step := range steps {
+		crossings = crossings.Add(step.Crossings)
+		if step.Stop {
+			stops++
+		}
+
+		fmt.Fprint(w, clearScreen)
+		for _, line := range day01.Frame(d.Size, d.Targets, step.End) {
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, day01.Caption(step))
+		fmt.Fprintf(w, "shown so far: %d stops, %v crossings\n", stops, crossings)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+	return nil
+}
+
+// streamDial prints the running counts of the rotations read from name, or
+// stdin if it is empty or "-", until they end or the process is interrupted,
+// and then the final counts.
+func streamDial(sets *paramFlag, targets marksFlag, name string, every int, interval time.Duration, stdout io.Writer) error {
+	s, err := dialSolver(sets, targets)
+	if err != nil {
+		return err
+	}
+	r := io.Reader(os.Stdin)
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	enc := json.NewEncoder(stdout)
+	var werr error
+	_, err = s.Stream(ctx, r, day01.StreamOptions{Every: every, Interval: interval}, func(c day01.Counts) {
+		if werr == nil {
+			werr = enc.Encode(c)
+		}
+	})
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	return errors.Join(err, werr)
+}
+
+func dialCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("dial", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	name := fs.String("input", "", "rotations file (default <root>/day01/input; with --stream, stdin when empty or -)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	var targets marksFlag
+	fs.Var(&targets, "targets", "comma separated marks to count (default 0)")
+	trace := fs.String("trace", "", "write every rotation as csv or json instead of the counts")
+	replay := fs.Bool("replay", false, "animate the rotations in the terminal")
+	delay := fs.Duration("delay", 200*time.Millisecond, "time each rotation is shown for with --replay")
+	from := fs.Int("from", 1, "first rotation to show with --trace or --replay")
+	count := fs.Int("count", 0, "number of rotations to show with --trace or --replay (0 for all)")
+	wantStops := fs.Int("want-stops", -1, "search for rotations giving this part 1 count")
+	wantPasses := fs.Int("want-passes", -1, "search for rotations giving this part 2 count")
+	maxRotations := fs.Int("max-rotations", 10, "longest rotation sequence to search")
+	maxDistance := fs.Int("max-distance", 100, "longest rotation to search")
+	lex := fs.Bool("lex", false, "search for the lexicographically smallest sequence instead of a shortest one")
+	stream := fs.Bool("stream", false, "read rotations as they arrive (from stdin unless --input) and print running counts as json lines, last when the input ends or on Ctrl-C")
+	every := fs.Int("every", 0, "print the running counts after this many rotations with --stream (0 for never)")
+	interval := fs.Duration("interval", 0, "print the running counts this often with --stream (0 for never)")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *stream {
+		if *trace != "" || *replay || *wantStops >= 0 || *wantPasses >= 0 {
+			return errors.New("--stream cannot be used with --trace, --replay, --want-stops or --want-passes")
+		}
+		if *every < 0 || *interval < 0 {
+			return errors.New("--every and --interval must not be negative")
+		}
+		return streamDial(sets, targets, *name, *every, *interval, stdout)
+	}
+	if *wantStops >= 0 || *wantPasses >= 0 {
+		if *trace != "" || *replay {
+			return errors.New("--want-stops and --want-passes cannot be used with --trace or --replay")
+		}
+		s, err := dialSolver(sets, targets)
+		if err != nil {
+			return err
+		}
+		rotations, err := s.Search(day01.Search{
+			Stops:         *wantStops,
+			Passes:        *wantPasses,
+			MaxRotations:  *maxRotations,
+			MaxDistance:   *maxDistance,
+			Lexicographic: *lex,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rot := range rotations {
+			fmt.Fprintln(stdout, rot)
+		}
+		return nil
+	}
+	if *name == "" {
+		*name = filepath.Join(*root, solver.Dir(1), "input")
+	}
+	if *trace != "" && *trace != "csv" && *trace != "json" {
+		return fmt.Errorf("unknown trace format %q (want csv or json)", *trace)
+	}
+	if *trace != "" && *replay {
+		return errors.New("--trace and --replay cannot be used together")
+	}
+	if *from < 1 || *count < 0 {
+		return errors.New("--from must be at least 1 and --count not negative")
+	}
+
+	s, err := dialSolver(sets, targets)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(*name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	d, steps, err := s.Trace(f)
+	if err != nil {
+		return err
+	}
+
+	steps = steps[min(*from-1, len(steps)):]
+	if *count > 0 {
+		steps = steps[:min(*count, len(steps))]
+	}
+	switch {
+	case *trace == "csv":
+		return day01.WriteTraceCSV(stdout, steps)
+	case *trace == "json":
+		return day01.WriteTraceJSON(stdout, steps)
+	case *replay:
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return replayDial(ctx, stdout, d, steps, *delay)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MARK\tSTOPS\tPASSES")
+	for i, m := range d.Targets {
+		fmt.Fprintf(tw, "%d\t%v\t%v\n", m, d.Stops[i], d.Passes[i])
+	}
+	if len(d.Targets) > 1 {
+		fmt.Fprintf(tw, "total\t%v\t%v\n", d.TotalStops(), d.TotalPasses())
+	}
+	return tw.Flush()
+}
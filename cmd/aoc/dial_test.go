@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abtris/aoc2025/day01"
+)
+
+func TestDialCmd(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := dialCmd([]string{"--root", "../..", "--input", "../../day01/input_test", "--targets", "0,32"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error counting: %v", err)
+	}
+	expected := "MARK   STOPS  PASSES\n0      3      6\n32     1      5\ntotal  4      11\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout.String())
+	}
+
+	stdout.Reset()
+	err = dialCmd([]string{"--input", "../../day01/input_test", "--set", "day01.size=10", "--set", "day01.start=0"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error counting: %v", err)
+	}
+	if stdout.String() != "MARK  STOPS  PASSES\n0     3      45\n" {
+		t.Errorf("Unexpected counts on a small dial:\n%s", stdout.String())
+	}
+
+	for _, args := range [][]string{{"--targets", "0,x"}, {"--targets", "100", "--input", "../../day01/input_test"}} {
+		if err := dialCmd(args, &stdout, &stderr); err == nil {
+			t.Errorf("dial %q: expected error", args)
+		}
+	}
+}
+
+func TestDialTrace(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := dialCmd([]string{"--input", "../../day01/input_test", "--trace", "csv", "--from", "9", "--count", "5"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error tracing: %v", err)
+	}
+	expected := "n,direction,distance,start,end,crossings,stop\n9,R,14,0,14,0,false\n10,L,82,14,32,1,false\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout.String())
+	}
+
+	for _, args := range [][]string{{"--trace", "xml"}, {"--trace", "csv", "--replay"}, {"--from", "0"}} {
+		if err := dialCmd(args, &stdout, &stderr); err == nil {
+			t.Errorf("dial %q: expected error", args)
+		}
+	}
+}
+
+func TestReplayDial(t *testing.T) {
+	d, steps, err := day01.Solver{}.Trace(strings.NewReader("L68\nL30\nR48\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := replayDial(context.Background(), &out, d, steps, 0); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), clearScreen); n != 3 {
+		t.Errorf("Expected 3 frames, got %d", n)
+	}
+	for _, want := range []string{"#1 L68: 50 -> 82", "#3 R48: 52 -> 0, crossed a target 1 times, stopped on a target", "shown so far: 1 stops, 2 crossings"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	// A cancelled replay stops after the frame on screen.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out.Reset()
+	replayDial(ctx, &out, d, steps, time.Hour)
+	if n := strings.Count(out.String(), clearScreen); n != 1 {
+		t.Errorf("Expected 1 frame after cancel, got %d", n)
+	}
+}
+
+func TestDialSearch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := dialCmd([]string{"--want-stops", "2", "--set", "day01.size=10", "--set", "day01.start=0", "--max-distance", "10"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error searching: %v", err)
+	}
+	if stdout.String() != "L10\nL10\n" {
+		t.Errorf("Expected two full turns, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	err = dialCmd([]string{"--want-passes", "1", "--targets", "49", "--lex"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error searching: %v", err)
+	}
+	if stdout.String() != "L1\n" {
+		t.Errorf("Expected L1, got %q", stdout.String())
+	}
+
+	err = dialCmd([]string{"--want-stops", "5", "--max-rotations", "2"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "no rotations") {
+		t.Errorf("Expected no rotations error, got %v", err)
+	}
+	if err := dialCmd([]string{"--want-stops", "1", "--trace", "csv"}, &stdout, &stderr); err == nil {
+		t.Error("Expected error with --trace")
+	}
+}
+
+func TestDialStream(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := dialCmd([]string{"--stream", "--every", "4", "--input", "../../day01/input_test"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error streaming: %v", err)
+	}
+	expected := `{"rotations":4,"position":95,"part1":"1","part2":"2"}
+{"rotations":8,"position":0,"part1":"3","part2":"5"}
+{"rotations":10,"position":32,"part1":"3","part2":"6"}
+`
+	if stdout.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout.String())
+	}
+
+	stdout.Reset()
+	if err := dialCmd([]string{"--stream", "--input", "../../day01/input_test"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Error streaming: %v", err)
+	}
+	if final := expected[strings.LastIndex(expected[:len(expected)-1], "\n")+1:]; stdout.String() != final {
+		t.Errorf("Expected only the final counts %s, got %s", final, stdout.String())
+	}
+
+	for _, args := range [][]string{{"--stream", "--replay"}, {"--stream", "--want-stops", "1"}, {"--stream", "--every", "-1"}} {
+		if err := dialCmd(args, &stdout, &stderr); err == nil {
+			t.Errorf("dial %q: expected error", args)
+		}
+	}
+}
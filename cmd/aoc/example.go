@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story"
+)
+
+func exampleCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to extract the example of (1-25)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	write := fs.Bool("write", false, "write the example to input_test")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *day < 1 || *day > 25 {
+		return errors.New("--day must be between 1 and 25")
+	}
+
+	dir := filepath.Join(*root, solver.Dir(*day))
+	s, err := story.Load(filepath.Join(dir, "story.md"))
+	if err != nil {
+		return err
+	}
+	if s.Parts[0].Example == "" {
+		return fmt.Errorf("no example found in %s", filepath.Join(dir, "story.md"))
+	}
+
+	fmt.Fprintln(stdout, s.Title)
+	for i, p := range s.Parts {
+		fmt.Fprintf(stdout, "Part %d: %s\n", i+1, describe(p))
+	}
+	if !*write {
+		fmt.Fprintf(stdout, "\n%s", s.Parts[0].Example)
+		return nil
+	}
+
+	filename := filepath.Join(dir, "input_test")
+	current, err := os.ReadFile(filename)
+	switch {
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return err
+	case bytes.Equal(bytes.TrimRight(current, "\n"), bytes.TrimRight([]byte(s.Parts[0].Example), "\n")):
+		fmt.Fprintf(stdout, "%s: up to date\n", filename)
+		return nil
+	case len(current) > 0:
+		return fmt.Errorf("refusing to overwrite %s: it differs from the story's example", filename)
+	}
+	if err := os.WriteFile(filename, []byte(s.Parts[0].Example), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s: written\n", filename)
+	return nil
+}
+
+// describe summarises the answers a story gives for one part.
+func describe(p story.Part) string {
+	out := "no example answer"
+	if p.Expected != nil {
+		out = "example answer " + p.Expected.String()
+	}
+	if p.Accepted != nil {
+		out += ", accepted " + p.Accepted.String()
+	}
+	return out
+}
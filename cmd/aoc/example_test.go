@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exampleStory = `--- Day 10: Sample ---
+For example:
+
+1
+2
+So, in this example, the total is 3.
+
+--- Part Two ---
+In this example, the product produces 2.
+`
+
+func TestExampleWritesInputTest(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "day10")
+	os.MkdirAll(dir, 0o755)
+	os.WriteFile(filepath.Join(dir, "story.md"), []byte(exampleStory), 0o644)
+	os.WriteFile(filepath.Join(dir, "input_test"), nil, 0o644)
+
+	var stdout, stderr bytes.Buffer
+	if err := exampleCmd([]string{"--day", "10", "--root", root, "--write"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Error extracting: %v", err)
+	}
+	for _, want := range []string{"Day 10: Sample", "Part 1: example answer 3", "Part 2: example answer 2", "written"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "input_test"))
+	if string(data) != "1\n2\n" {
+		t.Errorf("Expected %q, got %q", "1\n2\n", data)
+	}
+
+	os.WriteFile(filepath.Join(dir, "input_test"), []byte("9\n"), 0o644)
+	err := exampleCmd([]string{"--day", "10", "--root", root, "--write"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "refusing to overwrite") {
+		t.Errorf("Expected refusal, got %v", err)
+	}
+}
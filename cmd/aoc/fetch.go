@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/abtris/aoc2025/internal/site"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func fetchCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to download the input for (1-25)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	baseURL := fs.String("base-url", site.DefaultBaseURL, "website to download from")
+	cacheDir := fs.String("cache", site.CacheDir(), "directory downloaded inputs are cached in")
+	interval := fs.Duration("interval", site.DefaultInterval, "minimum time between requests to the site")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *day < 1 || *day > 25 {
+		return errors.New("--day must be between 1 and 25")
+	}
+
+	fetcher := &site.Fetcher{CacheDir: *cacheDir}
+	dest := filepath.Join(*root, solver.Dir(*day), "input")
+	// The session is only needed when the input has to be downloaded, so
+	// a missing token is reported by the client, not here.
+	session, _ := site.LoadSession()
+	fetcher.Client = site.NewClient(session)
+	fetcher.Client.BaseURL = *baseURL
+	fetcher.Client.Throttle.File = filepath.Join(*cacheDir, "last-request")
+	fetcher.Client.Throttle.Interval = *interval
+
+	result, err := fetcher.Fetch(context.Background(), *day, dest)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s: %s\n", dest, result)
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/site"
+)
+
+func TestFetchAgainstStandInServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2025/day/10/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("puzzle\n"))
+	}))
+	defer server.Close()
+	t.Setenv(site.SessionEnv, "secret")
+
+	root := t.TempDir()
+	args := []string{"--day", "10", "--root", root, "--cache", filepath.Join(root, "cache"), "--base-url", server.URL}
+
+	var stdout, stderr bytes.Buffer
+	if err := fetchCmd(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Error fetching: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "day10", "input"))
+	if err != nil || string(data) != "puzzle\n" {
+		t.Errorf("Expected downloaded input, got %q (%v)", data, err)
+	}
+
+	if err := fetchCmd([]string{"--day", "26"}, &stdout, &stderr); err == nil {
+		t.Error("Expected error for day 26")
+	}
+}
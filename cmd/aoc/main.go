@@ -0,0 +1,121 @@
+// Command aoc runs the Advent of Code 2025 solvers.
+//
+// Usage:
+//
+//	aoc run --day 7 --part 2 --input day07/input_test
+//	aoc run --all
+//	aoc run --day 8 --set day08.connections=10
+//	aoc params
+//	aoc dial --targets 0,50 --set day01.size=100
+//	aoc dial --trace json
+//	aoc dial --replay --from 100 --count 20
+//	aoc dial --want-stops 3 --want-passes 6 --max-distance 200
+//	tail -f rotations | aoc dial --stream --every 100 --interval 1s
+//	aoc verify
+//	aoc timings --json
+//	aoc tui
+//	aoc watch --day 10
+//	aoc bench --save
+//	aoc bench --compare --threshold 10
+//	aoc new --day 10
+//	aoc example --day 10 --write
+//	aoc fetch --day 10
+//	aoc submit --day 10 --part 1
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/abtris/aoc2025/internal/config"
+)
+
+// command is a single aoc subcommand.
+type command struct {
+	usage string
+	run   func(args []string, stdout, stderr io.Writer) error
+	// params is set for commands with a --set flag, which receive the
+	// solver parameters from the config file.
+	params bool
+}
+
+var commands = map[string]command{
+	"bench":   {usage: "benchmark every part and track regressions per commit", run: benchCmd, params: true},
+	"dial":    {usage: "count how often day 1's dial stops on and passes chosen marks", run: dialCmd, params: true},
+	"example": {usage: "extract the example input and answers from story.md", run: exampleCmd},
+	"fetch":   {usage: "download a day's puzzle input", run: fetchCmd},
+	"new":     {usage: "create the skeleton of a new day", run: newCmd},
+	"params":  {usage: "list and check the solver parameters", run: paramsCmd, params: true},
+	"run":     {usage: "run one day or all days", run: runCmd, params: true},
+	"submit":  {usage: "submit a day's answer", run: submitCmd},
+	"timings": {usage: "measure time and memory of every part", run: timingsCmd, params: true},
+	"tui":     {usage: "browse days, run parts and read the story", run: tuiCmd, params: true},
+	"verify":  {usage: "check every day against the accepted answers", run: verifyCmd},
+	"watch":   {usage: "re-run a day's tests and solver when its files change", run: watchCmd, params: true},
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: aoc <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// configArgs puts the flags from aoc.toml or aoc.json before the command
+// line arguments of the named command, so the command line wins.
+func configArgs(name string, args []string) ([]string, error) {
+	cfg, files, err := config.Find(config.Dirs()...)
+	if err != nil {
+		return nil, err
+	}
+	for table := range cfg.Commands {
+		if _, ok := commands[table]; !ok {
+			return nil, fmt.Errorf("%s: unknown command [%s]", strings.Join(files, ", "), table)
+		}
+	}
+
+	var prefix []string
+	prefix = append(prefix, cfg.Flags(name)...)
+	if commands[name].params {
+		for _, set := range cfg.Sets() {
+			prefix = append(prefix, "--set="+set)
+		}
+	}
+	return append(prefix, args...), nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n", os.Args[1])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	args, err := configArgs(os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := cmd.run(args, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "aoc %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
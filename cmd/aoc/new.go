@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/abtris/aoc2025/internal/scaffold"
+)
+
+func newCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to create (1-25)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *day < 1 || *day > 25 {
+		return errors.New("--day must be between 1 and 25")
+	}
+
+	written, err := scaffold.Generate(*root, *day)
+	for _, filename := range written {
+		fmt.Fprintf(stdout, "wrote %s\n", filename)
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewRefusesToOverwrite(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "day10"), 0o755)
+	os.WriteFile(filepath.Join(root, "day10", "main.go"), []byte("package day10\n"), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	err := newCmd([]string{"--day", "10", "--root", root}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "main.go") {
+		t.Errorf("Expected refusal naming main.go, got %v", err)
+	}
+
+	if err := newCmd([]string{"--day", "11", "--root", root}, &stdout, &stderr); err != nil {
+		t.Fatalf("Error creating day 11: %v", err)
+	}
+	if !strings.Contains(stdout.String(), filepath.Join(root, "day11", "main_test.go")) {
+		t.Errorf("Expected written files to be listed, got %q", stdout.String())
+	}
+}
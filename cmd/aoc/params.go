@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/abtris/aoc2025/internal/config"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// paramFlag collects repeated --set dayNN.name=value flags. Later values
+// replace earlier ones, so command line flags win over the config file.
+type paramFlag struct {
+	values map[int]solver.Values
+	sets   []string
+}
+
+func (p *paramFlag) String() string {
+	if p == nil {
+		return ""
+	}
+	return strings.Join(p.sets, ",")
+}
+
+func (p *paramFlag) Set(s string) error {
+	day, name, value, err := config.ParseSet(s)
+	if err != nil {
+		return err
+	}
+	if p.values == nil {
+		p.values = make(map[int]solver.Values)
+	}
+	if p.values[day] == nil {
+		p.values[day] = solver.Values{}
+	}
+	p.values[day][name] = value
+	p.sets = append(p.sets, s)
+	return nil
+}
+
+// setFlag adds the --set flag to fs.
+func setFlag(fs *flag.FlagSet) *paramFlag {
+	p := &paramFlag{}
+	fs.Var(p, "set", "set a solver parameter, e.g. day08.connections=10 (repeatable)")
+	return p
+}
+
+// configure applies the parameters to days. Parameters of days that are
+// not registered, and those the solver rejects, are errors even if the day
+// is not among days, so a typo never goes unnoticed.
+func (p *paramFlag) configure(days []solver.Day) ([]solver.Day, error) {
+	for n, values := range p.values {
+		d, ok := solver.Lookup(n)
+		if !ok {
+			return nil, fmt.Errorf("parameters set for day %d, which is not registered", n)
+		}
+		if _, err := d.Configure(values); err != nil {
+			return nil, err
+		}
+	}
+
+	configured := make([]solver.Day, len(days))
+	for i, d := range days {
+		var err error
+		if configured[i], err = d.Configure(p.values[d.Number]); err != nil {
+			return nil, err
+		}
+	}
+	return configured, nil
+}
+
+func paramsCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("params", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "only list this day's parameters")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []solver.Day{d}
+	}
+	if _, err := sets.configure(days); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARAMETER\tDEFAULT\tVALUE\tDESCRIPTION")
+	for _, d := range days {
+		for _, p := range d.Params() {
+			value := fmt.Sprint(p.Default)
+			if v, ok := sets.values[d.Number][p.Name]; ok {
+				value = fmt.Sprint(v)
+			}
+			fmt.Fprintf(tw, "%s.%s\t%d\t%s\t%s\n", solver.Dir(d.Number), p.Name, p.Default, value, p.Usage)
+		}
+	}
+	return tw.Flush()
+}
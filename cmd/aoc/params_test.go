@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParamsCmd(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := paramsCmd([]string{"--set", "day08.connections=10", "--set", "day08.connections=20"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Error listing parameters: %v", err)
+	}
+	for _, want := range []string{"day01.start        50       50", "day03.k            12       12", "day08.connections  1000     20"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--set", "day08.links=10"}, `day 8 has no parameter "links" (known: connections)`},
+		{[]string{"--set", "day03.k=0"}, "day 3: k must be at least 1, got 0"},
+		{[]string{"--set", "day01.start=100"}, "day 1: start must be less than size"},
+		{[]string{"--set", "day25.x=1"}, "parameters set for day 25, which is not registered"},
+		{[]string{"--set", "day07.x=1"}, "day 7 has no parameters"},
+	}
+	for _, tt := range tests {
+		err := paramsCmd(tt.args, &stdout, &stderr)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("params %q: expected error %q, got %v", tt.args, tt.expected, err)
+		}
+	}
+}
+
+func TestRunWithParams(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--day", "8", "--part", "1", "--input", "../../day08/input_test", "--set", "day08.connections=10"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error running: %v", err)
+	}
+	expected := "Part 1 - Product of three largest circuits: 40\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestConfigArgs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	os.WriteFile("aoc.toml", []byte("[run]\nformat = \"json\"\n[day08]\nconnections = 10\n"), 0o644)
+
+	args, err := configArgs("run", []string{"--day", "8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"--format=json", "--set=day08.connections=10", "--day", "8"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+
+	// Commands without --set get only their own flags.
+	if args, _ := configArgs("fetch", nil); len(args) != 0 {
+		t.Errorf("Expected no fetch flags, got %q", args)
+	}
+
+	os.WriteFile("aoc.toml", []byte("[runn]\nformat = \"json\"\n"), 0o644)
+	if _, err := configArgs("run", nil); err == nil || !strings.Contains(err.Error(), "unknown command [runn]") {
+		t.Errorf("Expected unknown command error, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// progressBar draws a single status line for the parts that report
+// progress. With several parts running at once the line shows whichever
+// reported last.
+type progressBar struct {
+	w        io.Writer
+	interval time.Duration
+
+	mu    sync.Mutex
+	last  time.Time
+	shown bool
+}
+
+// barWidth is the number of cells in the bar.
+const barWidth = 30
+
+func newProgressBar(w io.Writer) *progressBar {
+	return &progressBar{w: w, interval: 100 * time.Millisecond}
+}
+
+// callback returns the progress callback for one part.
+func (b *progressBar) callback(day, part int) solver.Progress {
+	return func(done, total int64) {
+		b.draw(day, part, done, total)
+	}
+}
+
+// draw redraws the line at most once per interval, except for the final
+// report of a part. Counts outside 0..total are drawn at the nearest end.
+func (b *progressBar) draw(day, part int, done, total int64) {
+	if total <= 0 {
+		return
+	}
+	done = min(max(done, 0), total)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if done < total && now.Sub(b.last) < b.interval {
+		return
+	}
+	b.last = now
+
+	filled := int(done * barWidth / total)
+	fmt.Fprintf(b.w, "\rday %02d part %d [%s%s] %3d%%", day, part,
+		strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled), done*100/total)
+	b.shown = true
+}
+
+// clear removes the line so other output starts on a clean line.
+func (b *progressBar) clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.shown {
+		fmt.Fprintf(b.w, "\r%s\r", strings.Repeat(" ", barWidth+25))
+		b.shown = false
+	}
+}
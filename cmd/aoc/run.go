@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func runCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to run (1-25)")
+	part := fs.Int("part", 0, "part to run (1 or 2, default both)")
+	input := fs.String("input", "", "input file (default dayNN/input)")
+	all := fs.Bool("all", false, "run every registered day")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	lenient := fs.Bool("lenient", false, "skip malformed input lines instead of failing")
+	format := fs.String("format", "text", "output format: "+strings.Join(report.Formats, ", "))
+	workers := fs.Int("j", 1, "number of parts to run at the same time")
+	timeout := fs.Duration("timeout", 0, "time limit for each part (0 for none)")
+	progress := fs.Bool("progress", false, "show the progress of long running parts on stderr")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *all == (*day != 0) {
+		return errors.New("exactly one of --day or --all is required")
+	}
+	if *all && *input != "" {
+		return errors.New("--input cannot be used with --all")
+	}
+	if *workers < 1 {
+		return errors.New("-j must be at least 1")
+	}
+	parts := []int{1, 2}
+	switch *part {
+	case 0:
+	case 1, 2:
+		parts = []int{*part}
+	default:
+		return fmt.Errorf("part %d does not exist", *part)
+	}
+
+	var days []solver.Day
+	if *all {
+		days = solver.Days()
+	} else {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []solver.Day{d}
+	}
+	days, err := sets.configure(days)
+	if err != nil {
+		return err
+	}
+
+	out, err := report.NewWriter(stdout, *format)
+	if err != nil {
+		return err
+	}
+	if text, ok := out.(*report.TextWriter); ok {
+		text.DayHeaders = *all
+	}
+
+	var jobs []*job
+	for _, d := range days {
+		filename := *input
+		if filename == "" {
+			filename = filepath.Join(*root, solver.Dir(d.Number), "input")
+		}
+		for _, p := range parts {
+			jobs = append(jobs, &job{day: d, part: p, filename: filename, done: make(chan struct{})})
+		}
+	}
+
+	// Ctrl-C stops the running parts, which report what they got so far.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var bar *progressBar
+	if *progress {
+		bar = newProgressBar(stderr)
+	}
+	runJobs(ctx, jobs, *workers, *timeout, *lenient, bar)
+
+	// Results are written in order as they become ready. Every part is
+	// written; failures are returned together at the end so machine
+	// readable output stays complete.
+	var errs []error
+	for _, j := range jobs {
+		<-j.done
+		if bar != nil {
+			bar.clear()
+		}
+		if j.err != nil {
+			errs = append(errs, j.err)
+		}
+		if err := out.Write(j.result); err != nil {
+			return err
+		}
+		if len(j.warnings) > 0 {
+			fmt.Fprintf(stderr, "warning: day %d (Part %d): skipped %d malformed input item(s), first: %v\n",
+				j.day.Number, j.part, len(j.warnings), j.warnings[0])
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// job is one part to run. done is closed once the result fields are set.
+type job struct {
+	day      solver.Day
+	part     int
+	filename string
+
+	done     chan struct{}
+	result   report.Result
+	warnings []error
+	err      error
+}
+
+// runJobs starts a pool of workers that run the jobs in order and returns
+// at once. Each part gets its own timeout when timeout is positive and
+// reports to bar unless it is nil.
+func runJobs(ctx context.Context, jobs []*job, workers int, timeout time.Duration, lenient bool, bar *progressBar) {
+	queue := make(chan *job)
+	go func() {
+		defer close(queue)
+		for _, j := range jobs {
+			queue <- j
+		}
+	}()
+
+	for range min(workers, len(jobs)) {
+		go func() {
+			for j := range queue {
+				partCtx, cancel := ctx, context.CancelFunc(func() {})
+				if timeout > 0 {
+					partCtx, cancel = context.WithTimeout(ctx, timeout)
+				}
+				if bar != nil {
+					partCtx = solver.WithProgress(partCtx, bar.callback(j.day.Number, j.part))
+				}
+				j.result, j.warnings, j.err = runPart(partCtx, j.day, j.part, j.filename, lenient)
+				cancel()
+				close(j.done)
+			}
+		}()
+	}
+}
+
+// runPart solves one part and returns it in the report schema together
+// with the problems skipped in lenient mode. A failure is recorded in the
+// result, with the partial answer of a part stopped early, and also
+// returned.
+func runPart(ctx context.Context, d solver.Day, part int, filename string, lenient bool) (report.Result, []error, error) {
+	r := report.Result{Day: d.Number, Part: part, Input: filename}
+	fail := func(err error) (report.Result, []error, error) {
+		r.Error = err.Error()
+		return r, nil, fmt.Errorf("day %d (Part %d): %w", d.Number, part, err)
+	}
+
+	solve, label, err := d.PartContext(part)
+	if err != nil {
+		return fail(err)
+	}
+	r.Label = label
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fail(err)
+	}
+	sum := sha256.Sum256(data)
+	r.InputHash = hex.EncodeToString(sum[:])
+
+	src := &input.Source{R: bytes.NewReader(data), Name: filename, Lenient: lenient}
+	start := time.Now()
+	answer, err := solve(ctx, src)
+	r.Duration = time.Since(start)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		err = fmt.Errorf("timed out after %v: %w", r.Duration.Round(time.Millisecond), err)
+	case errors.Is(err, context.Canceled):
+		err = fmt.Errorf("interrupted after %v: %w", r.Duration.Round(time.Millisecond), err)
+	}
+	if partial := (*solver.PartialError)(nil); errors.As(err, &partial) {
+		r.Answer = &partial.Answer
+	}
+	if err != nil {
+		return fail(err)
+	}
+	r.Answer = &answer
+	return r, src.Warnings, nil
+}
+
+// solveInput runs one part of d against filename and returns the answer
+// together with the problems skipped in lenient mode.
+func solveInput(d solver.Day, part int, filename string, lenient bool) (solver.Answer, []error, error) {
+	solve, _, err := d.Part(part)
+	if err != nil {
+		return solver.Answer{}, nil, err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return solver.Answer{}, nil, err
+	}
+	defer file.Close()
+
+	src := &input.Source{R: file, Name: filename, Lenient: lenient}
+	result, err := solve(src)
+	return result, src.Warnings, err
+}
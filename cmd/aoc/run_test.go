@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func TestRunDayWithTestInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--day", "7", "--part", "2", "--input", "../../day07/input_test"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error running: %v", err)
+	}
+
+	expected := "Part 2 - Timelines: 40\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--all", "--root", "../.."}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error running: %v", err)
+	}
+
+	for _, want := range []string{"Day 01\n", "Part 1 Password: ", "Day 09\n"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestRunFlagErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"--all", "--day", "1"},
+		{"--all", "--input", "x"},
+		{"--day", "99"},
+		{"--day", "1", "--part", "3", "--input", "../../day01/input_test"},
+	}
+
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		if err := runCmd(args, &stdout, &stderr); err == nil {
+			t.Errorf("runCmd(%q) expected error", args)
+		}
+	}
+}
+
+func TestRunStrictAndLenient(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(filename, []byte("162,817,812\n57,618,1x\n906,360,560\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--day", "8", "--part", "2", "--input", filename}, &stdout, &stderr)
+	expected := filename + `:2:8: expected integer, got "1x"`
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error containing %q, got %v", expected, err)
+	}
+
+	stdout.Reset()
+	err = runCmd([]string{"--day", "8", "--part", "2", "--input", filename, "--lenient"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Error running lenient: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "skipped 1 malformed input item(s)") {
+		t.Errorf("Expected warning count on stderr, got %q", stderr.String())
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"text", []string{"Part 1 - Beam splits: 21\n", "Part 2 - Timelines: 40\n"}},
+		{"json", []string{`"day": 7`, `"answer": "40"`, `"input_sha256": "`, `"duration_ns": `, `"error": ""`}},
+		{"csv", []string{"day,part,input,input_sha256,answer,duration_ns,error\n", "7,2,../../day07/input_test,"}},
+		{"tap", []string{"TAP version 13\n", "ok 1 - day 07 part 1: 21 # time=", "1..2\n"}},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		err := runCmd([]string{"--day", "7", "--input", "../../day07/input_test", "--format", tt.format}, &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("%s: error running: %v", tt.format, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("%s: expected output to contain %q, got:\n%s", tt.format, want, stdout.String())
+			}
+		}
+	}
+}
+
+func TestRunFormatReportsErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--day", "7", "--input", "missing", "--format", "json"}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("Expected an error for a missing input")
+	}
+	if strings.Count(stdout.String(), `"answer": null`) != 2 || !strings.Contains(stdout.String(), "no such file") {
+		t.Errorf("Expected both parts to report the error, got:\n%s", stdout.String())
+	}
+
+	if err := runCmd([]string{"--day", "7", "--format", "xml"}, &stdout, &stderr); err == nil {
+		t.Error("Expected error for an unknown format")
+	}
+}
+
+func TestRunAllInParallelKeepsOrder(t *testing.T) {
+	var sequential, parallel, stderr bytes.Buffer
+	if err := runCmd([]string{"--all", "--root", "../.."}, &sequential, &stderr); err != nil {
+		t.Fatalf("Error running: %v", err)
+	}
+	if err := runCmd([]string{"--all", "--root", "../..", "-j", "4"}, &parallel, &stderr); err != nil {
+		t.Fatalf("Error running in parallel: %v", err)
+	}
+	if parallel.String() != sequential.String() {
+		t.Errorf("Expected the same output as a sequential run, got:\n%s\nexpected:\n%s", parallel.String(), sequential.String())
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runCmd([]string{"--day", "9", "--part", "2", "--root", "../..", "--timeout", "1ms", "--format", "json"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "day 9 (Part 2): timed out after") {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "context deadline exceeded") {
+		t.Errorf("Expected the timeout in the output, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunJobsInterrupted(t *testing.T) {
+	d, _ := solver.Lookup(9)
+	j := &job{day: d, part: 2, filename: "../../day09/input", done: make(chan struct{})}
+
+	// Cancelling the context is what Ctrl-C does. It happens here once the
+	// bar has been drawn twice, so some pairs have been checked.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress := &cancelAfter{n: 2, cancel: cancel}
+	bar := newProgressBar(progress)
+	bar.interval = 0
+	runJobs(ctx, []*job{j}, 1, 0, false, bar)
+	<-j.done
+
+	var partial *solver.PartialError
+	if !errors.As(j.err, &partial) || !strings.Contains(j.err.Error(), "day 9 (Part 2): interrupted after") {
+		t.Fatalf("Expected an interrupted partial result, got %v", j.err)
+	}
+	if partial.Done == 0 || partial.Done >= partial.Total {
+		t.Errorf("Expected some pairs to be checked, got %+v", partial)
+	}
+	if j.result.Answer == nil || !j.result.Answer.Equal(partial.Answer) || j.result.Error == "" {
+		t.Errorf("Expected the result to record the partial answer and the failure, got %+v", j.result)
+	}
+	if !strings.Contains(progress.String(), "day 09 part 2 [") {
+		t.Errorf("Expected a progress bar, got %q", progress.String())
+	}
+}
+
+// cancelAfter calls cancel on the nth write.
+type cancelAfter struct {
+	bytes.Buffer
+	n      int
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) Write(p []byte) (int, error) {
+	if c.n--; c.n == 0 {
+		c.cancel()
+	}
+	return c.Buffer.Write(p)
+}
+
+func TestProgressBar(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf)
+	bar.draw(2, 1, 5, 10)
+	bar.draw(2, 1, 6, 10) // within the interval, skipped
+	bar.draw(2, 1, 10, 10)
+	bar.draw(2, 1, 12, 10) // past the end
+	bar.draw(2, 1, 1, 0)   // no total, skipped
+	bar.clear()
+
+	expected := "\rday 02 part 1 [###############...............]  50%" +
+		"\rday 02 part 1 [##############################] 100%" +
+		"\rday 02 part 1 [##############################] 100%" +
+		"\r" + strings.Repeat(" ", barWidth+25) + "\r"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/site"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func submitCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to submit (1-25)")
+	part := fs.Int("part", 0, "part to submit (1 or 2)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	input := fs.String("input", "", "input file (default dayNN/input)")
+	answer := fs.String("answer", "", "submit this answer instead of solving")
+	baseURL := fs.String("base-url", site.DefaultBaseURL, "website to submit to")
+	cacheDir := fs.String("cache", site.CacheDir(), "directory holding the guess history and rate limit")
+	interval := fs.Duration("interval", site.DefaultInterval, "minimum time between requests to the site")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *part != 1 && *part != 2 {
+		return errors.New("--part must be 1 or 2")
+	}
+	if *day < 1 || *day > 25 {
+		return errors.New("--day must be between 1 and 25")
+	}
+
+	if *answer == "" {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		filename := *input
+		if filename == "" {
+			filename = filepath.Join(*root, solver.Dir(*day), "input")
+		}
+		result, _, err := solveInput(d, *part, filename, false)
+		if err != nil {
+			return err
+		}
+		*answer = result.String()
+	}
+
+	guessFile := filepath.Join(*cacheDir, "guesses.json")
+	guesses, err := site.LoadGuesses(guessFile)
+	if err != nil {
+		return err
+	}
+	history := guesses.Part(*day, *part)
+	if err := history.Check(*answer, time.Now()); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	session, err := site.LoadSession()
+	if err != nil {
+		return err
+	}
+	client := site.NewClient(session)
+	client.BaseURL = *baseURL
+	client.Throttle.File = filepath.Join(*cacheDir, "last-request")
+	client.Throttle.Interval = *interval
+
+	resp, err := client.Submit(context.Background(), *day, *part, *answer)
+	if err != nil {
+		return err
+	}
+	history.Record(*answer, resp, time.Now())
+	if err := guesses.Save(guessFile); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Day %d Part %d: %s is %s\n", *day, *part, *answer, resp.Verdict)
+	if resp.Wait > 0 {
+		fmt.Fprintf(stdout, "Next submission possible in %s\n", resp.Wait)
+	}
+
+	switch resp.Verdict {
+	case site.Correct:
+		return acceptAnswer(filepath.Join(*root, answers.DefaultFile), *day, *part, *answer)
+	case site.AlreadySolved:
+		return nil
+	case site.Unknown:
+		return fmt.Errorf("unrecognised response: %s", resp.Message)
+	}
+	return fmt.Errorf("answer %s rejected: %s", *answer, resp.Verdict)
+}
+
+// acceptAnswer records a correct submission in the answer store.
+func acceptAnswer(storeFile string, day, part int, answer string) error {
+	store, err := answers.Load(storeFile)
+	if err != nil {
+		return err
+	}
+	parsed, err := solver.Parse(answer)
+	if err != nil {
+		return err
+	}
+	store.Set(day, "input", part, parsed)
+	return store.Save(storeFile)
+}
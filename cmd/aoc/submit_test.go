@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/site"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func TestSubmitAgainstStandInServer(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		if r.FormValue("answer") == "40" {
+			w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+			return
+		}
+		w.Write([]byte(`<article><p>That's not the right answer; your answer is too low.</p></article>`))
+	}))
+	defer server.Close()
+	t.Setenv(site.SessionEnv, "secret")
+
+	root := t.TempDir()
+	base := []string{"--day", "7", "--part", "2", "--root", root, "--cache", filepath.Join(root, "cache"), "--base-url", server.URL, "--interval", "0"}
+
+	var stdout, stderr bytes.Buffer
+	err := submitCmd(append(base, "--answer", "39"), &stdout, &stderr)
+	if err == nil || !strings.Contains(stdout.String(), "39 is too low") {
+		t.Errorf("Expected too low rejection, got %v: %s", err, stdout.String())
+	}
+
+	// The same wrong answer is refused locally without a request.
+	if err := submitCmd(append(base, "--answer", "39"), &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "not submitting") {
+		t.Errorf("Expected local refusal, got %v", err)
+	}
+
+	stdout.Reset()
+	if err := submitCmd(append(base, "--input", "../../day07/input_test"), &stdout, &stderr); err != nil {
+		t.Fatalf("Error submitting computed answer: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "40 is correct") {
+		t.Errorf("Expected correct verdict, got %q", stdout.String())
+	}
+
+	if err := submitCmd([]string{"--day", "0", "--part", "1", "--answer", "5", "--base-url", server.URL}, &stdout, &stderr); err == nil {
+		t.Error("Expected error for day 0")
+	}
+
+	if n := posts.Load(); n != 2 {
+		t.Errorf("Expected 2 posts, got %d", n)
+	}
+
+	store, _ := answers.Load(filepath.Join(root, answers.DefaultFile))
+	if got, ok := store.Lookup(7, "input", 2); !ok || !got.Equal(solver.Int(40)) {
+		t.Errorf("Expected correct answer in store, got %v", got)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/timing"
+)
+
+func timingsCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("timings", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "only time this day")
+	part := fs.Int("part", 0, "part to time (1 or 2, default both)")
+	name := fs.String("input", "input", "input file name inside each dayNN directory")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	benchTime := fs.Duration("benchtime", timing.DefaultBenchTime, "how long to repeat each part for")
+	asJSON := fs.Bool("json", false, "print the results as JSON")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []solver.Day{d}
+	}
+	days, err := sets.configure(days)
+	if err != nil {
+		return err
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	results, err := measureDays(days, parts, *root, *name, *benchTime)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	printTimings(stdout, results)
+	return nil
+}
+
+// measureDays times each part of days against the named input. Days
+// without that input are skipped.
+func measureDays(days []solver.Day, parts []int, root, name string, benchTime time.Duration) ([]timing.Result, error) {
+	results := []timing.Result{}
+	for _, d := range days {
+		filename := filepath.Join(root, solver.Dir(d.Number), name)
+		data, err := os.ReadFile(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range parts {
+			solve, _, err := d.Part(p)
+			if err != nil {
+				return nil, err
+			}
+			r := timing.Measure(solve, filename, data, benchTime)
+			r.Day, r.Part, r.Input = d.Number, p, name
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+// printTimings writes the summary table with a total of the time per run.
+func printTimings(w io.Writer, results []timing.Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPART\tTIME/OP\tALLOCS/OP\tBYTES/OP\tPEAK HEAP\tRUNS\t")
+	var total time.Duration
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%02d\t%d\tERROR: %s\t\t\t\t\t\n", r.Day, r.Part, r.Error)
+			continue
+		}
+		total += r.NsPerOp
+		fmt.Fprintf(tw, "%02d\t%d\t%v\t%d\t%s\t%s\t%d\t\n",
+			r.Day, r.Part, roundDuration(r.NsPerOp), r.AllocsPerOp, formatBytes(r.BytesPerOp), formatBytes(r.PeakHeap), r.Runs)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%v\t\t\t\t\t\n", roundDuration(total))
+	tw.Flush()
+}
+
+// roundDuration keeps three significant digits or so.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	case d >= time.Microsecond:
+		return d.Round(10 * time.Nanosecond)
+	}
+	return d
+}
+
+// formatBytes prints n in binary units.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/timing"
+)
+
+func TestTimingsJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"--day", "7", "--input", "input_test", "--root", "../..", "--benchtime", "1ms", "--json"}
+	if err := timingsCmd(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Error timing: %v", err)
+	}
+
+	var results []timing.Result
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("Error decoding %q: %v", stdout.String(), err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for i, expected := range []solver.Answer{solver.Int(21), solver.Int(40)} {
+		r := results[i]
+		if r.Day != 7 || r.Part != i+1 || r.Input != "input_test" || r.Runs < 1 || r.Error != "" {
+			t.Errorf("Unexpected result %+v", r)
+		}
+		if !r.Answer.Equal(expected) {
+			t.Errorf("Part %d: expected %d, got %d", i+1, expected, r.Answer)
+		}
+	}
+}
+
+func TestTimingsTable(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"--day", "1", "--part", "1", "--input", "input_test", "--root", "../..", "--benchtime", "1ms"}
+	if err := timingsCmd(args, &stdout, &stderr); err != nil {
+		t.Fatalf("Error timing: %v", err)
+	}
+	for _, want := range []string{"TIME/OP", "PEAK HEAP", "01     1", "TOTAL"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n        uint64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{3 << 20, "3.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", tt.n, got, tt.expected)
+		}
+	}
+}
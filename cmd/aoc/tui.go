@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/dashboard"
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/timing"
+)
+
+func tuiCmd(args []string, stdout, stderr io.Writer) error {
+	return tui(args, os.Stdin, stdout, stderr)
+}
+
+// tui reads the commands from in so tests can script a session. When in is
+// a terminal it reads single keypresses, unless --lines asks for a command
+// per line.
+func tui(args []string, in io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	storeFile := fs.String("store", "", "answer store (default <root>/"+answers.DefaultFile+")")
+	historyFile := fs.String("history", "", "benchmark history for the timings (default <root>/"+timing.DefaultHistoryFile+")")
+	width := fs.Int("width", envInt("COLUMNS", 100), "screen width")
+	height := fs.Int("height", envInt("LINES", 30), "screen height")
+	timeout := fs.Duration("timeout", 0, "time limit for each run (0 for none)")
+	lines := fs.Bool("lines", false, "read a command per line even on a terminal")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *storeFile == "" {
+		*storeFile = filepath.Join(*root, answers.DefaultFile)
+	}
+	if *historyFile == "" {
+		*historyFile = filepath.Join(*root, timing.DefaultHistoryFile)
+	}
+
+	store, err := answers.Load(*storeFile)
+	if err != nil {
+		return err
+	}
+	history, err := timing.LoadHistory(*historyFile)
+	if err != nil {
+		return err
+	}
+
+	days, err := sets.configure(solver.Days())
+	if err != nil {
+		return err
+	}
+
+	d := dashboard.New(days, store)
+	d.Width, d.Height = *width, *height
+	if len(history) > 0 {
+		for _, b := range history[len(history)-1].Benches {
+			if b.Input == answers.Inputs[0] {
+				d.Timings[[2]int{b.Day, b.Part}] = time.Duration(timing.Summarize(b.NsPerOp()).Median)
+			}
+		}
+	}
+	d.LoadStory = func(day int) ([]string, error) {
+		f, err := os.Open(filepath.Join(*root, solver.Dir(day), "story.md"))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return input.Lines(f)
+	}
+	d.Run = func(day solver.Day, part int, name string) report.Result {
+		filename := filepath.Join(*root, solver.Dir(day.Number), name)
+		day, err := inputDay(day, store, name, sets.values[day.Number])
+		if err != nil {
+			return report.Result{Day: day.Number, Part: part, Input: filename, Error: err.Error()}
+		}
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if *timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+		}
+		defer cancel()
+		r, _, _ := runPart(ctx, day, part, filename, false)
+		return r
+	}
+
+	if f, ok := in.(*os.File); ok && !*lines && isTerminal(f) {
+		restore, err := keypressMode(f)
+		if err == nil {
+			defer restore()
+			d.KeyMode = true
+			return runKeys(d, in, stdout)
+		}
+	}
+	return runDashboard(d, in, stdout)
+}
+
+// runKeys redraws d after every read of keypresses from in until the user
+// quits or in ends. The cursor is hidden meanwhile.
+func runKeys(d *dashboard.Dashboard, in io.Reader, out io.Writer) error {
+	fmt.Fprint(out, hideCursor)
+	defer fmt.Fprint(out, showCursor)
+
+	buf := make([]byte, 64)
+	for {
+		if err := d.Render(out); err != nil {
+			return err
+		}
+		n, err := in.Read(buf)
+		for _, cmd := range dashboard.Keys(buf[:n]) {
+			if !d.Handle(cmd) {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Cursor visibility escapes.
+const (
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+)
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// keypressMode switches the terminal f to deliver every key as it is
+// pressed, without echo and with Ctrl-C as an ordinary key, and returns a
+// function restoring the previous settings. It uses stty, so it fails
+// where there is none and the caller falls back to line mode.
+func keypressMode(f *os.File) (restore func(), err error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = f
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1", "time", "0"); err != nil {
+		return nil, err
+	}
+	return func() { stty(saved) }, nil
+}
+
+// runDashboard redraws d after every line read from in until the user quits
+// or in ends.
+func runDashboard(d *dashboard.Dashboard, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if err := d.Render(out); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		if !d.Handle(scanner.Text()) {
+			return nil
+		}
+	}
+}
+
+// envInt returns the integer in the environment variable name, or def.
+func envInt(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
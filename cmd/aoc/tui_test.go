@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/dashboard"
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func TestTUISession(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	in := strings.NewReader("7\ni\n\np\nr\nq\nnot read\n")
+	args := []string{"--root", "../..", "--history", filepath.Join(t.TempDir(), "none.json"), "--width", "120", "--height", "20"}
+	if err := tui(args, in, &stdout, &stderr); err != nil {
+		t.Fatalf("Error running the dashboard: %v", err)
+	}
+
+	screens := strings.Split(stdout.String(), "\x1b[H\x1b[2J")
+	if len(screens) != 7 {
+		t.Fatalf("Expected 6 screens, got %d", len(screens)-1)
+	}
+	for _, want := range []string{"--- Day 7: Laboratories ---", "part 2 | input_test", "Part 2 - Timelines: 40", "matches the accepted answer"} {
+		if !strings.Contains(screens[6], want) {
+			t.Errorf("Expected the last screen to contain %q, got:\n%s", want, screens[6])
+		}
+	}
+	if !strings.Contains(screens[4], "Part 1 - Beam splits: 21") {
+		t.Errorf("Expected part 1 on the example, got:\n%s", screens[4])
+	}
+}
+
+func TestTUIUsesInputParams(t *testing.T) {
+	// Day 8's example is solved with the 10 connections the store records
+	// for it.
+	var stdout, stderr bytes.Buffer
+	in := strings.NewReader("8\ni\nr\nq\n")
+	args := []string{"--root", "../..", "--history", filepath.Join(t.TempDir(), "none.json")}
+	if err := tui(args, in, &stdout, &stderr); err != nil {
+		t.Fatalf("Error running the dashboard: %v", err)
+	}
+	screens := strings.Split(stdout.String(), "\x1b[H\x1b[2J")
+	if last := screens[len(screens)-1]; !strings.Contains(last, ": 40") || !strings.Contains(last, "matches the accepted answer") {
+		t.Errorf("Expected the accepted example answer 40, got:\n%s", last)
+	}
+}
+
+func TestRunKeys(t *testing.T) {
+	d := dashboard.New(solver.Days(), answers.Store{})
+	d.KeyMode = true
+	var ran []string
+	d.Run = func(day solver.Day, part int, input string) report.Result {
+		ran = append(ran, fmt.Sprintf("%d/%d/%s", day.Number, part, input))
+		return report.Result{Day: day.Number, Part: part}
+	}
+
+	var stdout bytes.Buffer
+	// Select day 7, move down to day 8, switch to part 2 and the example
+	// and run, then quit before the last key.
+	keys := strings.NewReader("7\x1b[B\x1b[Ci\rqj")
+	if err := runKeys(d, keys, &stdout); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ran, ",") != "8/2/input_test" {
+		t.Errorf("Expected one run of day 8 part 2 on input_test, got %v", ran)
+	}
+	if out := stdout.String(); !strings.HasPrefix(out, hideCursor) || !strings.HasSuffix(out, showCursor) {
+		t.Errorf("Expected the cursor hidden while running, got %q", out)
+	}
+}
+
+func TestTUIEndOfInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"--root", "../..", "--history", filepath.Join(t.TempDir(), "none.json")}
+	if err := tui(args, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("Error running the dashboard: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "DAY  SOLVED") {
+		t.Errorf("Expected one screen, got:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Verification statuses.
+const (
+	statusPass    = "PASS"
+	statusFail    = "FAIL"
+	statusError   = "ERROR"
+	statusMissing = "MISSING"
+)
+
+// check is the outcome of running one part against one input.
+type check struct {
+	day      int
+	input    string
+	part     int
+	status   string
+	expected string
+	got      string
+}
+
+func verifyCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	storeFile := fs.String("store", "", "answer store (default <root>/"+answers.DefaultFile+")")
+	update := fs.Bool("update", false, "record the current answers as accepted")
+	day := fs.Int("day", 0, "only verify this day")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *storeFile == "" {
+		*storeFile = filepath.Join(*root, answers.DefaultFile)
+	}
+
+	store, err := answers.Load(*storeFile)
+	if err != nil {
+		return err
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		d, ok := solver.Lookup(*day)
+		if !ok {
+			return fmt.Errorf("day %d is not registered", *day)
+		}
+		days = []solver.Day{d}
+	}
+
+	checks := verify(store, *root, days)
+	printChecks(stdout, checks)
+
+	failed := 0
+	for _, c := range checks {
+		if c.status == statusError {
+			failed++
+		}
+	}
+	if *update {
+		if failed > 0 {
+			return fmt.Errorf("not updating: %d check(s) could not be solved", failed)
+		}
+		for _, c := range checks {
+			if c.status == statusFail || c.status == statusMissing {
+				answer, _ := solver.Parse(c.got)
+				store.Set(c.day, c.input, c.part, answer)
+			}
+		}
+		return store.Save(*storeFile)
+	}
+
+	for _, c := range checks {
+		if c.status == statusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// verify runs the given days against each input file that exists and
+// compares the results with the store.
+func verify(store answers.Store, root string, days []solver.Day) []check {
+	var checks []check
+	for _, d := range days {
+		for _, name := range answers.Inputs {
+			filename := filepath.Join(root, solver.Dir(d.Number), name)
+			if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			for _, part := range []int{1, 2} {
+				c := check{day: d.Number, input: name, part: part}
+				expected, known := store.Lookup(d.Number, name, part)
+				if known {
+					c.expected = expected.String()
+				}
+
+				var got solver.Answer
+				configured, err := inputDay(d, store, name, nil)
+				if err == nil {
+					got, _, err = solveInput(configured, part, filename, false)
+				}
+				switch {
+				case err != nil:
+					c.status, c.got = statusError, err.Error()
+				case !known:
+					c.status, c.got = statusMissing, got.String()
+				case got.Equal(expected):
+					c.status, c.got = statusPass, got.String()
+				default:
+					c.status, c.got = statusFail, got.String()
+				}
+				checks = append(checks, c)
+			}
+		}
+	}
+	return checks
+}
+
+// inputDay returns d configured for the named input: the parameters the
+// store records for that input on top of values.
+func inputDay(d solver.Day, store answers.Store, name string, values solver.Values) (solver.Day, error) {
+	params := store.Params(d.Number, name)
+	if len(params) == 0 {
+		return d, nil
+	}
+	merged := maps.Clone(values)
+	if merged == nil {
+		merged = solver.Values{}
+	}
+	maps.Copy(merged, params)
+	return d.Configure(merged)
+}
+
+// printChecks writes the pass/fail table. Failures show the expected answer
+// next to the one we got.
+func printChecks(w io.Writer, checks []check) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tINPUT\tPART\tSTATUS\tEXPECTED\tGOT")
+	for _, c := range checks {
+		expected := c.expected
+		if expected == "" {
+			expected = "-"
+		}
+		fmt.Fprintf(tw, "%02d\t%s\t%d\t%s\t%s\t%s\n", c.day, c.input, c.part, c.status, expected, c.got)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// TestAcceptedAnswers is the real-input regression test: every registered
+// day must still produce the answers recorded in answers.json.
+func TestAcceptedAnswers(t *testing.T) {
+	store, err := answers.Load(filepath.Join("../..", answers.DefaultFile))
+	if err != nil {
+		t.Fatalf("Error loading store: %v", err)
+	}
+
+	for _, c := range verify(store, "../..", solver.Days()) {
+		if c.status == statusFail || c.status == statusError {
+			t.Errorf("day %02d %s part %d: %s, expected %s, got %s", c.day, c.input, c.part, c.status, c.expected, c.got)
+		}
+	}
+}
+
+func TestVerifyReportsDiffs(t *testing.T) {
+	storeFile := filepath.Join(t.TempDir(), answers.DefaultFile)
+	store := answers.Store{}
+	store.Set(7, "input_test", 1, solver.Int(22))
+	if err := store.Save(storeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := verifyCmd([]string{"--root", "../..", "--store", storeFile, "--day", "7"}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("Expected verify to fail")
+	}
+	if !strings.Contains(stdout.String(), "07   input_test  1     FAIL") || !strings.Contains(stdout.String(), "22 ") {
+		t.Errorf("Expected a FAIL row with the expected answer, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := verifyCmd([]string{"--root", "../..", "--store", storeFile, "--day", "7", "--update"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Error updating: %v", err)
+	}
+	updated, err := answers.Load(storeFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := updated.Lookup(7, "input_test", 1); !ok || !got.Equal(solver.Int(21)) {
+		t.Errorf("Expected updated answer 21, got %v", got)
+	}
+}
+
+func TestVerifyUpdateRefusesErrors(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "day07"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "day07", "input_test"), []byte("abc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	storeFile := filepath.Join(root, answers.DefaultFile)
+
+	var stdout, stderr bytes.Buffer
+	err := verifyCmd([]string{"--root", root, "--day", "7", "--update"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "not updating") {
+		t.Errorf("Expected update to be refused, got %v", err)
+	}
+	if _, err := os.Stat(storeFile); !os.IsNotExist(err) {
+		t.Errorf("Expected no store to be written, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/watch"
+)
+
+func watchCmd(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	day := fs.Int("day", 0, "day to watch (1-25)")
+	root := fs.String("root", ".", "repository root containing the dayNN directories")
+	interval := fs.Duration("interval", watch.DefaultInterval, "time between polls of the files")
+	debounce := fs.Duration("debounce", watch.DefaultDebounce, "quiet time after a change before running")
+	sets := setFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *day < 1 || *day > 25 {
+		return errors.New("--day must be between 1 and 25")
+	}
+
+	dir := filepath.Join(*root, solver.Dir(*day))
+	w := watch.New(dir, func(name string) bool {
+		return strings.HasSuffix(name, ".go") || name == "input" || name == "input_test"
+	})
+	w.Interval, w.Debounce = *interval, *debounce
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s := &watchSession{day: *day, root: *root, out: stdout, sets: sets.sets, exec: execCommand, last: make(map[int]string)}
+	// Edits saved during the first run are picked up by the first Wait.
+	if err := w.Mark(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "watching %s, Ctrl-C to stop\n", dir)
+	s.cycle(ctx)
+	for {
+		changed, err := w.Wait(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel := make([]string, len(changed))
+		for i, name := range changed {
+			rel[i] = filepath.Base(name)
+		}
+		fmt.Fprintf(stdout, "\n[%s] changed: %s\n", time.Now().Format(time.TimeOnly), strings.Join(rel, ", "))
+		s.cycle(ctx)
+	}
+}
+
+// commandFunc runs a program in dir and returns its standard output and
+// standard error.
+type commandFunc func(ctx context.Context, dir string, name string, args ...string) (stdout, stderr []byte, err error)
+
+func execCommand(ctx context.Context, dir string, name string, args ...string) ([]byte, []byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// watchSession re-runs one day and remembers the answers of the previous
+// run. The solver is run with go run so edits are compiled in.
+type watchSession struct {
+	day  int
+	root string
+	out  io.Writer
+	// sets holds the --set parameters passed on to aoc run.
+	sets []string
+	exec commandFunc
+	// last holds the previous answer or error of each part.
+	last map[int]string
+}
+
+// outputTail is how many lines of failing output are shown.
+const outputTail = 20
+
+// cycle runs the day's tests and, when they pass, the real input.
+func (s *watchSession) cycle(ctx context.Context) {
+	pkg := "./" + solver.Dir(s.day)
+	start := time.Now()
+	stdout, stderr, err := s.exec(ctx, s.root, "go", "test", pkg)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(s.out, "tests: FAIL (%v)\n%s", time.Since(start).Round(time.Millisecond), tail(append(stdout, stderr...), outputTail))
+		return
+	}
+	fmt.Fprintf(s.out, "tests: ok (%v)\n", time.Since(start).Round(time.Millisecond))
+
+	args := []string{"run", "./cmd/aoc", "run", "--day", strconv.Itoa(s.day), "--format", "json"}
+	for _, set := range s.sets {
+		args = append(args, "--set", set)
+	}
+	stdout, stderr, err = s.exec(ctx, s.root, "go", args...)
+	if ctx.Err() != nil {
+		return
+	}
+	var results []report.Result
+	if jsonErr := json.Unmarshal(stdout, &results); jsonErr != nil {
+		if err == nil {
+			err = jsonErr
+		}
+		fmt.Fprintf(s.out, "run: %v\n%s", err, tail(stderr, outputTail))
+		return
+	}
+
+	for _, r := range results {
+		now := "error: " + r.Error
+		if r.Error == "" {
+			now = r.Answer.String()
+		}
+		line := fmt.Sprintf("Part %d: %s", r.Part, now)
+		switch prev, seen := s.last[r.Part]; {
+		case !seen:
+		case prev == now:
+			line += " (unchanged)"
+		default:
+			line += fmt.Sprintf(" (was %s)", prev)
+		}
+		fmt.Fprintf(s.out, "%s [%v]\n", line, r.Duration.Round(time.Microsecond))
+		s.last[r.Part] = now
+	}
+}
+
+// tail returns the last n lines of out.
+func tail(out []byte, n int) string {
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	if len(lines) > n {
+		lines = append([]string{"..."}, lines[len(lines)-n:]...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
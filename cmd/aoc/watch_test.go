@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeGo answers go test and go run from a script of outputs.
+type fakeGo struct {
+	testErr error
+	runJSON string
+	calls   []string
+}
+
+func (f *fakeGo) exec(ctx context.Context, dir, name string, args ...string) ([]byte, []byte, error) {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	if args[0] == "test" {
+		if f.testErr != nil {
+			return []byte("--- FAIL: TestSolverWithStoryExample\nFAIL\n"), nil, f.testErr
+		}
+		return []byte("ok\n"), nil, nil
+	}
+	return []byte(f.runJSON), []byte("aoc run: failed\n"), nil
+}
+
+func TestWatchCycle(t *testing.T) {
+	fake := &fakeGo{runJSON: `[{"day": 3, "part": 1, "answer": "357", "duration_ns": 1000, "error": ""},
+		{"day": 3, "part": 2, "answer": null, "error": "boom"}]`}
+	var out bytes.Buffer
+	s := &watchSession{day: 3, root: ".", out: &out, exec: fake.exec, last: make(map[int]string)}
+
+	s.cycle(context.Background())
+	expected := []string{"tests: ok (", "Part 1: 357 [1µs]\n", "Part 2: error: boom [0s]\n"}
+	for _, want := range expected {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if len(fake.calls) != 2 || fake.calls[0] != "go test ./day03" || fake.calls[1] != "go run ./cmd/aoc run --day 3 --format json" {
+		t.Errorf("Unexpected commands %q", fake.calls)
+	}
+
+	out.Reset()
+	fake.runJSON = `[{"day": 3, "part": 1, "answer": "357", "error": ""}, {"day": 3, "part": 2, "answer": "3121910778619", "error": ""}]`
+	s.cycle(context.Background())
+	for _, want := range []string{"Part 1: 357 (unchanged)", "Part 2: 3121910778619 (was error: boom)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	// Parameters are passed on to aoc run.
+	fake.calls = nil
+	s.sets = []string{"day03.k=2"}
+	s.cycle(context.Background())
+	if len(fake.calls) != 2 || fake.calls[1] != "go run ./cmd/aoc run --day 3 --format json --set day03.k=2" {
+		t.Errorf("Unexpected commands %q", fake.calls)
+	}
+	s.sets = nil
+
+	// Failing tests skip the real input.
+	out.Reset()
+	fake.calls = nil
+	fake.testErr = errors.New("exit status 1")
+	s.cycle(context.Background())
+	if !strings.Contains(out.String(), "tests: FAIL") || !strings.Contains(out.String(), "--- FAIL: TestSolverWithStoryExample") || len(fake.calls) != 1 {
+		t.Errorf("Expected only the failing tests, got %q and:\n%s", fake.calls, out.String())
+	}
+
+	// Output that is not JSON shows the run's stderr.
+	out.Reset()
+	fake.testErr = nil
+	fake.runJSON = "not json"
+	s.cycle(context.Background())
+	if !strings.Contains(out.String(), "run: invalid character") || !strings.Contains(out.String(), "aoc run: failed") {
+		t.Errorf("Expected the run error, got:\n%s", out.String())
+	}
+}
+
+func TestTail(t *testing.T) {
+	if got := tail([]byte("a\nb\nc\n"), 2); got != "...\nb\nc\n" {
+		t.Errorf("Expected the last two lines, got %q", got)
+	}
+	if got := tail([]byte("a\n"), 2); got != "a\n" {
+		t.Errorf("Expected the whole output, got %q", got)
+	}
+}
+
+func TestWatchFlagErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := watchCmd([]string{"--day", "0"}, &stdout, &stderr); err == nil {
+		t.Error("Expected error without a day")
+	}
+}
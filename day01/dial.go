@@ -0,0 +1,146 @@
+package day01
+
+import (
+	"fmt"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Rotation is a single "L68" or "R48" instruction.
+type Rotation struct {
+	Direction byte
+	Distance  int
+}
+
+func (r Rotation) String() string {
+	return fmt.Sprintf("%c%d", r.Direction, r.Distance)
+}
+
+// Dial is a safe dial with Size marks numbered 0 to Size-1 and a pointer at
+// Position. Rotating it counts, for each mark in Targets, how often a
+// rotation ends on the mark (Stops, as in part 1) and how often any click
+// lands on it (Passes, as in part 2).
+type Dial struct {
+	Size     int
+	Position int
+	Targets  []int
+	Stops    []solver.Answer
+	Passes   []solver.Answer
+}
+
+// NewDial returns a dial of size marks pointing at start that counts the
+// target marks, or only 0 when there are none.
+func NewDial(size, start int, targets []int) (*Dial, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("dial size must be at least 1, got %d", size)
+	}
+	if start < 0 || start >= size {
+		return nil, fmt.Errorf("start %d is not a mark on a dial of size %d", start, size)
+	}
+	if len(targets) == 0 {
+		targets = []int{0}
+	}
+	seen := make(map[int]bool, len(targets))
+	for _, t := range targets {
+		if t < 0 || t >= size {
+			return nil, fmt.Errorf("target %d is not a mark on a dial of size %d", t, size)
+		}
+		if seen[t] {
+			return nil, fmt.Errorf("target %d given twice", t)
+		}
+		seen[t] = true
+	}
+
+	return &Dial{
+		Size:     size,
+		Position: start,
+		Targets:  append([]int(nil), targets...),
+		Stops:    make([]solver.Answer, len(targets)),
+		Passes:   make([]solver.Answer, len(targets)),
+	}, nil
+}
+
+// clicksTo returns how many clicks in the rotation's direction take the
+// pointer from position to mark, a full turn when it is already there.
+func (d *Dial) clicksTo(position, mark int, direction byte) int {
+	clicks := mark - position
+	if direction == 'L' {
+		clicks = -clicks
+	}
+	clicks = (clicks%d.Size + d.Size) % d.Size
+	if clicks == 0 {
+		clicks = d.Size
+	}
+	return clicks
+}
+
+// passes returns how many clicks of rot from position land on mark, the
+// last included. It works per full turn rather than per click, so huge
+// distances cost nothing.
+func (d *Dial) passes(position, mark int, rot Rotation) int {
+	// Every full turn passes each mark exactly once
+	n := rot.Distance / d.Size
+	if rot.Distance%d.Size >= d.clicksTo(position, mark, rot.Direction) {
+		n++
+	}
+	return n
+}
+
+// after returns where rot leaves the pointer when it starts at position.
+func (d *Dial) after(position int, rot Rotation) int {
+	rest := rot.Distance % d.Size
+	if rot.Direction == 'L' {
+		rest = d.Size - rest
+	}
+	return (position + rest) % d.Size
+}
+
+// Step is what one rotation did to the dial.
+type Step struct {
+	N int // 1-based number of the rotation
+	Rotation
+	Start, End int
+	// Crossings counts the clicks that landed on a target, the last
+	// included.
+	Crossings solver.Answer
+	// Stop is set when the rotation ended on a target.
+	Stop bool
+}
+
+// Rotate turns the dial, updates the counts and returns what happened.
+func (d *Dial) Rotate(rot Rotation) Step {
+	step := Step{Rotation: rot, Start: d.Position}
+	for i, t := range d.Targets {
+		n := solver.Int(d.passes(d.Position, t, rot))
+		d.Passes[i] = d.Passes[i].Add(n)
+		step.Crossings = step.Crossings.Add(n)
+	}
+
+	d.Position = d.after(d.Position, rot)
+	for i, t := range d.Targets {
+		if d.Position == t {
+			d.Stops[i] = d.Stops[i].Add(solver.Int(1))
+			step.Stop = true
+		}
+	}
+	step.End = d.Position
+	return step
+}
+
+// TotalStops returns the stops summed over all targets.
+func (d *Dial) TotalStops() solver.Answer {
+	var total solver.Answer
+	for _, n := range d.Stops {
+		total = total.Add(n)
+	}
+	return total
+}
+
+// TotalPasses returns the passes summed over all targets.
+func (d *Dial) TotalPasses() solver.Answer {
+	var total solver.Answer
+	for _, n := range d.Passes {
+		total = total.Add(n)
+	}
+	return total
+}
@@ -1,132 +1,169 @@
-package main
+package day01
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"errors"
+	"io"
 	"strconv"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
-// Part 1: Count only when dial ends at 0 after a rotation
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
+// Solver solves day 1. Start is where the dial points first, Size is the
+// number of marks on it and Targets are the marks counted; the zero value
+// uses the puzzle's dial of 100 marks starting at 50 and counts 0. A zero
+// Size means 100 and a zero Start means 50 only together with it, so an
+// explicit dial can start at 0.
+type Solver struct {
+	Start   int
+	Size    int
+	Targets []int
+}
 
-	position := 50 // Starting position
-	count := 0     // Count of times dial points at 0
+// The puzzle's dial, used by the zero Solver and as the parameter defaults.
+const (
+	defaultStart = 50
+	defaultSize  = 100
+)
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
+// dial returns a new dial, applying the defaults.
+func (s Solver) dial() (*Dial, error) {
+	size, start := s.Size, s.Start
+	if size == 0 {
+		size = defaultSize
+		if start == 0 {
+			start = defaultStart
 		}
+	}
+	return NewDial(size, start, s.Targets)
+}
 
-		// Parse direction and distance
-		direction := line[0]
-		distance, err := strconv.Atoi(line[1:])
-		if err != nil {
-			return 0, fmt.Errorf("invalid rotation: %s", line)
-		}
+// Params lists the dial's start and size.
+func (Solver) Params() []solver.Param {
+	return []solver.Param{
+		{Name: "start", Default: defaultStart, Min: 0, Usage: "position the dial starts at"},
+		{Name: "size", Default: defaultSize, Min: 1, Usage: "number of marks on the dial"},
+	}
+}
 
-		// Apply rotation
-		if direction == 'L' {
-			position = (position - distance) % 100
-			if position < 0 {
-				position += 100
-			}
-		} else if direction == 'R' {
-			position = (position + distance) % 100
-		}
+// WithParams returns a solver for the given dial, keeping its targets.
+func (s Solver) WithParams(values solver.Values) (solver.Solver, error) {
+	if values["start"] >= values["size"] {
+		return nil, errors.New("start must be less than size")
+	}
+	s.Start, s.Size = values["start"], values["size"]
+	return s, nil
+}
 
-		// Check if dial points at 0
-		if position == 0 {
-			count++
-		}
+// parseRotations reads one rotation per non-blank line.
+func parseRotations(r io.Reader) ([]Rotation, error) {
+	sec, err := input.Read(r)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	var rotations []Rotation
+	for i := range sec.Lines {
+		rot, ok, err := parseRotation(sec, i)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rotations = append(rotations, rot)
+		}
 	}
 
-	return count, nil
+	return rotations, nil
 }
 
-// Part 2: Count every time dial passes through 0 during rotation
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
+// parseRotation parses line i of sec. It reports false for blank lines and
+// for lines skipped in lenient mode.
+func parseRotation(sec input.Section, i int) (Rotation, bool, error) {
+	line := sec.Lines[i]
+	if len(line) == 0 {
+		return Rotation{}, false, nil
 	}
-	defer file.Close()
 
-	position := 50 // Starting position
-	count := 0     // Count of times dial points at 0
+	// Parse direction and distance
+	direction := line[0]
+	if direction != 'L' && direction != 'R' {
+		return Rotation{}, false, sec.Errorf(i, 1, "expected L or R, got %q", direction)
+	}
+	distance, err := strconv.Atoi(line[1:])
+	if err != nil || distance < 0 {
+		return Rotation{}, false, sec.Errorf(i, 2, "expected distance, got %q", line[1:])
+	}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
-		}
+	return Rotation{Direction: direction, Distance: distance}, true, nil
+}
 
-		// Parse direction and distance
-		direction := line[0]
-		distance, err := strconv.Atoi(line[1:])
-		if err != nil {
-			return 0, fmt.Errorf("invalid rotation: %s", line)
-		}
+// Turn reads the rotations and applies them to the solver's dial.
+func (s Solver) Turn(r io.Reader) (*Dial, error) {
+	return s.turn(r, nil)
+}
 
-		// Count how many times we pass through 0 during rotation
-		if direction == 'L' {
-			// Moving left (decreasing)
-			for i := 1; i <= distance; i++ {
-				pos := (position - i) % 100
-				if pos < 0 {
-					pos += 100
-				}
-				if pos == 0 {
-					count++
-				}
-			}
-			position = (position - distance) % 100
-			if position < 0 {
-				position += 100
-			}
-		} else if direction == 'R' {
-			// Moving right (increasing)
-			for i := 1; i <= distance; i++ {
-				pos := (position + i) % 100
-				if pos == 0 {
-					count++
-				}
-			}
-			position = (position + distance) % 100
-		}
+// Trace is Turn that also returns every rotation's step.
+func (s Solver) Trace(r io.Reader) (*Dial, []Step, error) {
+	var steps []Step
+	d, err := s.turn(r, func(step Step) { steps = append(steps, step) })
+	return d, steps, err
+}
+
+// turn applies the rotations, passing each step to record if it is set.
+// Both parts and the trace go through here, so they always agree.
+func (s Solver) turn(r io.Reader, record func(Step)) (*Dial, error) {
+	d, err := s.dial()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	rotations, err := parseRotations(r)
+	if err != nil {
+		return nil, err
 	}
 
-	return count, nil
+	for i, rot := range rotations {
+		step := d.Rotate(rot)
+		if record != nil {
+			step.N = i + 1
+			record(step)
+		}
+	}
+	return d, nil
 }
 
-func main() {
-	result, err := solve("input")
+// Part 1: Count only when dial ends at a target after a rotation
+func (s Solver) Part1(r io.Reader) (solver.Answer, error) {
+	d, err := s.Turn(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
+		return solver.Answer{}, err
 	}
-	fmt.Printf("Part 1 Password: %d\n", result)
+	return d.TotalStops(), nil
+}
 
-	result2, err := solvePart2("input")
+// Part 2: Count every time dial passes through a target during rotation
+func (s Solver) Part2(r io.Reader) (solver.Answer, error) {
+	d, err := s.Turn(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
+		return solver.Answer{}, err
 	}
-	fmt.Printf("Part 2 Password: %d\n", result2)
+	return d.TotalPasses(), nil
+}
+
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
+
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 1,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 Password", "Part 2 Password"},
+	})
 }
@@ -1,6 +1,18 @@
-package main
+package day01
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestSolveWithTestInput(t *testing.T) {
 	result, err := solve("input_test")
@@ -8,8 +20,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 3
-	if result != expected {
+	expected := solver.Int(3)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -20,8 +32,639 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 6
-	if result != expected {
+	expected := solver.Int(6)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `L68
+L30
+R48
+L5
+R60
+L55
+L1
+L99
+R14
+L82`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(3)},
+		{2, Solver{}.Part2, solver.Int(6)},
+		{1, Solver{Start: 50, Size: 100}.Part1, solver.Int(3)},
+		{2, Solver{Start: 50, Size: 100}.Part2, solver.Int(6)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithSmallDial(t *testing.T) {
+	d, err := solver.Day{Number: 1, Solver: Solver{}}.Configure(solver.Values{"start": 0, "size": 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, d.Solver.Part1, solver.Int(2)},
+		{2, d.Solver.Part2, solver.Int(3)},
+	}
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader("R5\nR5\nL20\n"))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverDefaults(t *testing.T) {
+	tests := []struct {
+		s           Solver
+		size, start int
+	}{
+		{Solver{}, 100, 50},
+		{Solver{Start: 10}, 100, 10},
+		{Solver{Size: 10}, 10, 0},
+		{Solver{Start: 3, Size: 10}, 10, 3},
+	}
+
+	for _, tt := range tests {
+		d, err := tt.s.dial()
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.s, err)
+		}
+		if d.Size != tt.size || d.Position != tt.start {
+			t.Errorf("%+v: expected a dial of %d starting at %d, got %d starting at %d", tt.s, tt.size, tt.start, d.Size, d.Position)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
+
+func TestParseRotationsStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"L68\nX30\n", `2:1: expected L or R, got 'X'`},
+		{"L68\nR4x\n", `2:2: expected distance, got "4x"`},
+		{"R-5\n", `1:2: expected distance, got "-5"`},
+	}
+
+	for _, tt := range tests {
+		_, err := parseRotations(strings.NewReader(tt.input))
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("parseRotations(%q) error = %v, expected %q", tt.input, err, tt.expected)
+		}
+	}
+}
+
+func TestParseRotationsLenient(t *testing.T) {
+	src := &input.Source{R: strings.NewReader("L68\nX30\nR48\n"), Lenient: true}
+	rotations, err := parseRotations(src)
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	if len(rotations) != 2 || len(src.Warnings) != 1 {
+		t.Errorf("Expected 2 rotations and 1 warning, got %v and %v", rotations, src.Warnings)
+	}
+}
+
+func TestSolverWithHugeRotations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected solver.Answer
+	}{
+		{"R1000000000\n", solver.Int(10000000)},
+		{"L1000000050\n", solver.Int(10000001)},
+		{"R50\nL9223372036854775807\n", solver.Int(92233720368547759)},
+		{"R9223372036854775807\nR9223372036854775807\n", solver.Int(184467440737095516)},
+	}
+	for _, tt := range tests {
+		result, err := Solver{}.Part2(strings.NewReader(tt.input))
+		if err != nil {
+			t.Fatalf("Error solving part 2: %v", err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part2(%q): expected %d, got %d", tt.input, tt.expected, result)
+		}
+	}
+}
+
+// bruteZeros is the original click-by-click Part 2 loop, kept as the
+// reference for the dial.
+func bruteZeros(position int, rotations []Rotation, size int) (int, int) {
+	count := 0
+	for _, rot := range rotations {
+		direction, distance := rot.Direction, rot.Distance
+		if direction == 'L' {
+			for i := 1; i <= distance; i++ {
+				pos := (position - i) % size
+				if pos < 0 {
+					pos += size
+				}
+				if pos == 0 {
+					count++
+				}
+			}
+			position = (position - distance) % size
+			if position < 0 {
+				position += size
+			}
+		} else if direction == 'R' {
+			for i := 1; i <= distance; i++ {
+				pos := (position + i) % size
+				if pos == 0 {
+					count++
+				}
+			}
+			position = (position + distance) % size
+		}
+	}
+	return position, count
+}
+
+// FuzzPart2 checks the closed-form count against the brute-force loop on
+// random dials and rotation sequences. Every two bytes of data make one
+// rotation: the low bit of the first picks the direction and the rest
+// the distance, up to a few full turns.
+func FuzzPart2(f *testing.F) {
+	f.Add(uint8(50), uint8(100), []byte{68, 1, 30, 0, 49, 0})
+	f.Add(uint8(0), uint8(100), []byte{200, 0, 201, 0, 0, 0, 1, 0})
+	f.Add(uint8(0), uint8(1), []byte{7, 3})
+	f.Add(uint8(3), uint8(7), []byte{255, 255, 14, 0, 15, 1})
+
+	f.Fuzz(func(t *testing.T, start, size uint8, data []byte) {
+		if size == 0 {
+			return
+		}
+		s := Solver{Start: int(start) % int(size), Size: int(size)}
+
+		var rotations []Rotation
+		var text strings.Builder
+		for i := 0; i+1 < len(data); i += 2 {
+			rot := Rotation{Direction: 'R', Distance: (int(data[i])>>1 | int(data[i+1])<<7) % 2000}
+			if data[i]&1 == 1 {
+				rot.Direction = 'L'
+			}
+			rotations = append(rotations, rot)
+			fmt.Fprintln(&text, rot)
+		}
+
+		_, expected := bruteZeros(s.Start, rotations, s.Size)
+		result, err := s.Part2(strings.NewReader(text.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(solver.Int(expected)) {
+			t.Errorf("dial %d/%d, rotations %v: expected %d, got %d", s.Start, s.Size, rotations, expected, result)
+		}
+
+		// Each rotation on its own must also agree, end position included.
+		d, _ := NewDial(s.Size, s.Start, nil)
+		for _, rot := range rotations {
+			start, before := d.Position, d.Passes[0]
+			d.Rotate(rot)
+			bruteEnd, bruteCount := bruteZeros(start, []Rotation{rot}, s.Size)
+			if d.Position != bruteEnd || !d.Passes[0].Equal(before.Add(solver.Int(bruteCount))) {
+				t.Fatalf("%v from %d on dial of %d: got %d, %d, expected %d, %d more", rot, start, s.Size, d.Position, d.Passes[0], bruteEnd, bruteCount)
+			}
+		}
+	})
+}
+
+func TestNewDialErrors(t *testing.T) {
+	tests := []struct {
+		size, start int
+		targets     []int
+		expected    string
+	}{
+		{0, 0, nil, "dial size must be at least 1, got 0"},
+		{10, 10, nil, "start 10 is not a mark on a dial of size 10"},
+		{10, 0, []int{3, -1}, "target -1 is not a mark on a dial of size 10"},
+		{10, 0, []int{3, 3}, "target 3 given twice"},
+	}
+	for _, tt := range tests {
+		_, err := NewDial(tt.size, tt.start, tt.targets)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("NewDial(%d, %d, %v): expected error %q, got %v", tt.size, tt.start, tt.targets, tt.expected, err)
+		}
+	}
+}
+
+func TestDialTargets(t *testing.T) {
+	rotations, err := parseRotations(strings.NewReader(example + "\nR250\nL3\nL100\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{1, 7, 100} {
+		targets := []int{0, size / 2, size - 1}
+		if size == 1 {
+			targets = []int{0}
+		}
+		d, err := NewDial(size, size/3, targets)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Walk click by click, counting every target.
+		stops := make([]int, len(targets))
+		passes := make([]int, len(targets))
+		position := size / 3
+		for _, rot := range rotations {
+			step := 1
+			if rot.Direction == 'L' {
+				step = size - 1
+			}
+			for i := 0; i < rot.Distance; i++ {
+				position = (position + step) % size
+				for j, m := range targets {
+					if position == m {
+						passes[j]++
+					}
+				}
+			}
+			for j, m := range targets {
+				if position == m {
+					stops[j]++
+				}
+			}
+			d.Rotate(rot)
+		}
+
+		for j, m := range targets {
+			if !d.Stops[j].Equal(solver.Int(stops[j])) || !d.Passes[j].Equal(solver.Int(passes[j])) {
+				t.Errorf("Size %d mark %d: expected %d stops and %d passes, got %d and %d", size, m, stops[j], passes[j], d.Stops[j], d.Passes[j])
+			}
+		}
+		if d.Position != position {
+			t.Errorf("Size %d: expected to end at %d, got %d", size, position, d.Position)
+		}
+	}
+}
+
+func TestSolverWithTargets(t *testing.T) {
+	s := Solver{Targets: []int{0, 50}}
+	d, err := s.Turn(strings.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The example stops on 0 three times and passes it six times.
+	if !d.Stops[0].Equal(solver.Int(3)) || !d.Passes[0].Equal(solver.Int(6)) {
+		t.Errorf("Expected 3 stops and 6 passes at 0, got %d and %d", d.Stops[0], d.Passes[0])
+	}
+
+	part1, _ := s.Part1(strings.NewReader(example))
+	part2, _ := s.Part2(strings.NewReader(example))
+	if !part1.Equal(d.TotalStops()) || !part2.Equal(d.TotalPasses()) {
+		t.Errorf("Expected parts to count all targets, got %d and %d", part1, part2)
+	}
+
+	configured, err := s.WithParams(solver.Values{"start": 0, "size": 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := configured.(Solver); len(got.Targets) != 2 || got.Size != 10 {
+		t.Errorf("Expected WithParams to keep the targets, got %+v", got)
+	}
+}
+
+func TestTraceMatchesParts(t *testing.T) {
+	for _, filename := range []string{"input_test", "input"} {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := Solver{}
+		_, steps, err := s.Trace(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var stops, crossings solver.Answer
+		for i, step := range steps {
+			if step.N != i+1 || (i > 0 && step.Start != steps[i-1].End) {
+				t.Fatalf("%s: step %d does not follow on: %+v", filename, i, step)
+			}
+			if step.Stop {
+				stops = stops.Add(solver.Int(1))
+			}
+			crossings = crossings.Add(step.Crossings)
+		}
+
+		part1, _ := s.Part1(strings.NewReader(string(data)))
+		part2, _ := s.Part2(strings.NewReader(string(data)))
+		if !stops.Equal(part1) || !crossings.Equal(part2) {
+			t.Errorf("%s: trace gives %d and %d, parts give %d and %d", filename, stops, crossings, part1, part2)
+		}
+	}
+}
+
+func TestTraceExport(t *testing.T) {
+	_, steps, err := Solver{}.Trace(strings.NewReader("L68\nL30\nR48\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var csvOut, jsonOut strings.Builder
+	if err := WriteTraceCSV(&csvOut, steps); err != nil {
+		t.Fatal(err)
+	}
+	expectedCSV := "n,direction,distance,start,end,crossings,stop\n1,L,68,50,82,1,false\n2,L,30,82,52,0,false\n3,R,48,52,0,1,true\n"
+	if csvOut.String() != expectedCSV {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expectedCSV, csvOut.String())
+	}
+
+	if err := WriteTraceJSON(&jsonOut, steps[2:]); err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `[
+  {
+    "n": 3,
+    "direction": "R",
+    "distance": 48,
+    "start": 52,
+    "end": 0,
+    "crossings": "1",
+    "stop": true
+  }
+]
+`
+	if jsonOut.String() != expectedJSON {
+		t.Errorf("Expected JSON:\n%s\ngot:\n%s", expectedJSON, jsonOut.String())
+	}
+
+	expectedCaption := "#3 R48: 52 -> 0, crossed a target 1 times, stopped on a target"
+	if got := Caption(steps[2]); got != expectedCaption {
+		t.Errorf("Expected %q, got %q", expectedCaption, got)
+	}
+}
+
+func TestFrame(t *testing.T) {
+	frame := Frame(4, []int{2}, 1)
+	if len(frame) != frameRows {
+		t.Fatalf("Expected %d rows, got %d", frameRows, len(frame))
+	}
+	expected := map[int]string{
+		0:             "             .",
+		frameRows / 2: ".            1            @",
+		frameRows - 1: "             +",
+	}
+	for row, line := range expected {
+		if frame[row] != line {
+			t.Errorf("Row %d: expected %q, got %q", row, line, frame[row])
+		}
+	}
+
+	// A big dial still draws every target and the pointer.
+	text := strings.Join(Frame(1000, []int{0, 250}, 500), "\n")
+	if strings.Count(text, "+") != 2 || strings.Count(text, "@") != 1 || !strings.Contains(text, "500") {
+		t.Errorf("Unexpected frame:\n%s", text)
+	}
+}
+
+// rotationsText renders rotations as puzzle input.
+func rotationsText(rotations []Rotation) string {
+	var b strings.Builder
+	for _, rot := range rotations {
+		fmt.Fprintln(&b, rot)
+	}
+	return b.String()
+}
+
+func TestSearchAgainstEnumeration(t *testing.T) {
+	const maxRotations, maxDistance = 3, 4
+	s := Solver{Start: 2, Size: 5, Targets: []int{0, 3}}
+
+	// Enumerate every sequence in lexicographic order, a sequence before
+	// its extensions, with its counts from the real solver.
+	type found struct {
+		rotations     []Rotation
+		stops, passes int
+	}
+	var all []found
+	var walk func(prefix []Rotation)
+	walk = func(prefix []Rotation) {
+		text := rotationsText(prefix)
+		stops, _ := s.Part1(strings.NewReader(text))
+		passes, _ := s.Part2(strings.NewReader(text))
+		p1, _ := stops.Int64()
+		p2, _ := passes.Int64()
+		all = append(all, found{append([]Rotation(nil), prefix...), int(p1), int(p2)})
+		if len(prefix) == maxRotations {
+			return
+		}
+		for _, direction := range []byte{'L', 'R'} {
+			for distance := 1; distance <= maxDistance; distance++ {
+				walk(append(prefix, Rotation{Direction: direction, Distance: distance}))
+			}
+		}
+	}
+	walk(nil)
+
+	for stops := -1; stops <= 4; stops++ {
+		for passes := -1; passes <= 8; passes++ {
+			if stops < 0 && passes < 0 {
+				continue
+			}
+			var smallest, shortest *found
+			for i := range all {
+				f := &all[i]
+				if (stops >= 0 && f.stops != stops) || (passes >= 0 && f.passes != passes) {
+					continue
+				}
+				if smallest == nil {
+					smallest = f
+				}
+				if shortest == nil || len(f.rotations) < len(shortest.rotations) {
+					shortest = f
+				}
+			}
+
+			for _, lex := range []bool{false, true} {
+				expected := shortest
+				if lex {
+					expected = smallest
+				}
+				q := Search{Stops: stops, Passes: passes, MaxRotations: maxRotations, MaxDistance: maxDistance, Lexicographic: lex}
+				got, err := s.Search(q)
+				if expected == nil {
+					if err != ErrNoRotations {
+						t.Errorf("%+v: expected no rotations, got %v %v", q, got, err)
+					}
+					continue
+				}
+				if err != nil || rotationsText(got) != rotationsText(expected.rotations) {
+					t.Errorf("%+v: expected %v, got %v %v", q, expected.rotations, got, err)
+				}
+			}
+		}
+	}
+}
+
+func TestSearchPuzzleDial(t *testing.T) {
+	// Reproduce the example's counts on the puzzle's dial. Each rotation
+	// stops at most once and passes 0 at most twice, so 3 is the minimum.
+	rotations, err := Solver{}.Search(Search{Stops: 3, Passes: 6, MaxRotations: 10, MaxDistance: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotations) != 3 {
+		t.Errorf("Expected 3 rotations, got %v", rotations)
+	}
+	text := rotationsText(rotations)
+	part1, _ := Solver{}.Part1(strings.NewReader(text))
+	part2, _ := Solver{}.Part2(strings.NewReader(text))
+	if !part1.Equal(solver.Int(3)) || !part2.Equal(solver.Int(6)) {
+		t.Errorf("%v gives %d and %d, expected 3 and 6", rotations, part1, part2)
+	}
+
+	// Rotations longer than 700 pass 0 more than 6 times, so a huge bound
+	// finds what 700 does.
+	bounded, _ := Solver{}.Search(Search{Stops: 3, Passes: 6, MaxRotations: 10, MaxDistance: 700})
+	huge, err := Solver{}.Search(Search{Stops: 3, Passes: 6, MaxRotations: 10, MaxDistance: 1_000_000_000})
+	if err != nil || rotationsText(huge) != rotationsText(bounded) {
+		t.Errorf("Expected %v with a huge distance bound, got %v (%v)", bounded, huge, err)
+	}
+	if _, err := (Solver{}).Search(Search{Stops: 2, Passes: -1, MaxRotations: 3, MaxDistance: 1_000_000_000}); err != nil {
+		t.Errorf("Expected stops-only search with a huge distance bound to succeed, got %v", err)
+	}
+
+	if _, err := (Solver{}).Search(Search{Stops: -1, Passes: -1, MaxRotations: 1, MaxDistance: 1}); err == nil {
+		t.Error("Expected error without wanted counts")
+	}
+	if _, err := (Solver{}).Search(Search{Stops: 1, Passes: -1, MaxRotations: 1, MaxDistance: 0}); err == nil {
+		t.Error("Expected error without moves")
+	}
+}
+
+func TestStreamEvery(t *testing.T) {
+	var emitted []Counts
+	final, err := Solver{}.Stream(context.Background(), strings.NewReader(example), StreamOptions{Every: 3}, func(c Counts) {
+		emitted = append(emitted, c)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rotations []int64
+	for _, c := range emitted {
+		rotations = append(rotations, c.Rotations)
+	}
+	if fmt.Sprint(rotations) != "[3 6 9 10]" {
+		t.Errorf("Expected counts after 3, 6, 9 and 10 rotations, got %v", rotations)
+	}
+	if final != emitted[len(emitted)-1] || !final.Part1.Equal(solver.Int(3)) || !final.Part2.Equal(solver.Int(6)) || final.Position != 32 {
+		t.Errorf("Unexpected final counts %+v", final)
+	}
+	// After L68 L30 R48 the dial has stopped on 0 once and passed it twice.
+	if first := emitted[0]; !first.Part1.Equal(solver.Int(1)) || !first.Part2.Equal(solver.Int(2)) {
+		t.Errorf("Unexpected running counts %+v", first)
+	}
+}
+
+func TestStreamEndless(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan Counts, 100)
+	result := make(chan error, 1)
+	var final Counts
+	go func() {
+		var err error
+		final, err = Solver{}.Stream(ctx, pr, StreamOptions{Interval: 5 * time.Millisecond}, func(c Counts) {
+			updates <- c
+		})
+		result <- err
+	}()
+
+	// The timer reports even before anything arrives.
+	if c := <-updates; c.Rotations != 0 || c.Position != 50 {
+		t.Errorf("Expected an empty report, got %+v", c)
+	}
+
+	io.WriteString(pw, "L50\nR100\n")
+	for c := range updates {
+		if c.Rotations == 2 {
+			if !c.Part1.Equal(solver.Int(2)) || !c.Part2.Equal(solver.Int(2)) {
+				t.Errorf("Unexpected running counts %+v", c)
+			}
+			break
+		}
+	}
+
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if final.Rotations != 2 {
+		t.Errorf("Expected final counts after 2 rotations, got %+v", final)
+	}
+}
+
+func TestStreamReportsOnCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var emitted []Counts
+	result := make(chan error, 1)
+	go func() {
+		_, err := Solver{}.Stream(ctx, pr, StreamOptions{}, func(c Counts) {
+			emitted = append(emitted, c)
+		})
+		result <- err
+	}()
+
+	// The second write is only read once every rotation of the first has
+	// been handed over.
+	io.WriteString(pw, "L50\nR100\nR5\n")
+	io.WriteString(pw, "\n")
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(emitted) != 1 || emitted[0].Rotations != 3 || !emitted[0].Part2.Equal(solver.Int(2)) {
+		t.Errorf("Expected the final counts after 3 rotations, got %+v", emitted)
+	}
+}
+
+func TestStreamParseError(t *testing.T) {
+	final, err := Solver{}.Stream(context.Background(), strings.NewReader("L50\n\nX1\nR5\n"), StreamOptions{}, func(Counts) {})
+	if err == nil || err.Error() != `3:1: expected L or R, got 'X'` {
+		t.Errorf("Expected parse error on line 3, got %v", err)
+	}
+	if final.Rotations != 1 || !final.Part1.Equal(solver.Int(1)) {
+		t.Errorf("Expected counts up to the error, got %+v", final)
+	}
+
+	src := &input.Source{R: strings.NewReader("L50\nX1\nR5\n"), Lenient: true}
+	final, err = Solver{}.Stream(context.Background(), src, StreamOptions{Every: 1}, nil)
+	if err != nil || final.Rotations != 2 || len(src.Warnings) != 1 {
+		t.Errorf("Expected lenient stream to skip the bad line, got %+v %v %v", final, err, src.Warnings)
+	}
+}
@@ -0,0 +1,186 @@
+package day01
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoRotations is returned by Search when no sequence within the bounds
+// gives the wanted counts.
+var ErrNoRotations = errors.New("no rotations within the bounds give the wanted counts")
+
+// Search describes the rotation sequences the reverse solver looks for.
+type Search struct {
+	// Stops and Passes are the wanted part 1 and part 2 counts; -1
+	// accepts any count.
+	Stops, Passes int
+	// MaxRotations bounds the length of the sequence and MaxDistance the
+	// distance of each rotation, which is at least 1.
+	MaxRotations, MaxDistance int
+	// Lexicographic asks for the lexicographically smallest sequence
+	// within the bounds instead of a shortest one. Rotations compare by
+	// direction, L before R, then by distance, and a sequence comes before
+	// its extensions.
+	Lexicographic bool
+}
+
+// searchState is the part of the dial's state the wanted counts depend on.
+// Counts that are not wanted stay 0.
+type searchState struct {
+	position, stops, passes int
+}
+
+// searcher explores rotations on a dial, sharing its counting code.
+type searcher struct {
+	Search
+	dial  *Dial
+	moves []Rotation
+}
+
+// apply returns the state after rot and whether it can still reach the
+// wanted counts, which only ever grow.
+func (s *searcher) apply(st searchState, rot Rotation) (searchState, bool) {
+	next := searchState{position: s.dial.after(st.position, rot), stops: st.stops, passes: st.passes}
+	if s.Stops >= 0 {
+		for _, t := range s.dial.Targets {
+			if next.position == t {
+				next.stops++
+			}
+		}
+		if next.stops > s.Stops {
+			return next, false
+		}
+	}
+	if s.Passes >= 0 {
+		for _, t := range s.dial.Targets {
+			next.passes += s.dial.passes(st.position, t, rot)
+			if next.passes > s.Passes {
+				return next, false
+			}
+		}
+	}
+	return next, true
+}
+
+func (s *searcher) done(st searchState) bool {
+	return (s.Stops < 0 || st.stops == s.Stops) && (s.Passes < 0 || st.passes == s.Passes)
+}
+
+// shortest searches breadth first. Nodes are queued in the order of their
+// paths and the moves in order, so the first path to reach any state is
+// the lexicographically smallest of the shortest ones.
+func (s *searcher) shortest(start searchState) ([]Rotation, bool) {
+	type node struct {
+		state  searchState
+		parent int
+		rot    Rotation
+		depth  int
+	}
+	nodes := []node{{state: start, parent: -1}}
+	seen := map[searchState]bool{start: true}
+
+	for i := 0; i < len(nodes); i++ {
+		n := nodes[i]
+		if s.done(n.state) {
+			path := make([]Rotation, n.depth)
+			for j := i; nodes[j].parent >= 0; j = nodes[j].parent {
+				path[nodes[j].depth-1] = nodes[j].rot
+			}
+			return path, true
+		}
+		if n.depth == s.MaxRotations {
+			continue
+		}
+		for _, rot := range s.moves {
+			next, ok := s.apply(n.state, rot)
+			if ok && !seen[next] {
+				seen[next] = true
+				nodes = append(nodes, node{state: next, parent: i, rot: rot, depth: n.depth + 1})
+			}
+		}
+	}
+	return nil, false
+}
+
+// smallest searches depth first in move order, so the first sequence found
+// is the lexicographically smallest. States are remembered with the most
+// rotations they failed to finish in.
+func (s *searcher) smallest(start searchState) ([]Rotation, bool) {
+	failed := make(map[searchState]int)
+	var path []Rotation
+
+	var visit func(st searchState, remaining int) bool
+	visit = func(st searchState, remaining int) bool {
+		if s.done(st) {
+			return true
+		}
+		if remaining == 0 {
+			return false
+		}
+		if r, ok := failed[st]; ok && r >= remaining {
+			return false
+		}
+		for _, rot := range s.moves {
+			next, ok := s.apply(st, rot)
+			if !ok {
+				continue
+			}
+			path = append(path, rot)
+			if visit(next, remaining-1) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		failed[st] = remaining
+		return false
+	}
+
+	if !visit(start, s.MaxRotations) {
+		return nil, false
+	}
+	return path, true
+}
+
+// Search finds rotations that take the solver's dial from its start to the
+// wanted counts. The effort grows with the dial size, the wanted counts and
+// both bounds.
+func (s Solver) Search(q Search) ([]Rotation, error) {
+	if q.Stops < 0 && q.Passes < 0 {
+		return nil, errors.New("want a stop count, a pass count or both")
+	}
+	if q.MaxRotations < 0 || q.MaxDistance < 1 {
+		return nil, fmt.Errorf("bounds must allow rotations, got %d rotations of at most %d", q.MaxRotations, q.MaxDistance)
+	}
+	d, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	// A rotation longer than Size*(Passes+1) passes every mark more than
+	// Passes times, and without wanted passes one longer than Size ends
+	// where a shorter one does, so longer moves can never help.
+	maxDistance := q.MaxDistance
+	if q.Passes < 0 {
+		maxDistance = min(maxDistance, d.Size)
+	} else if q.Passes < maxDistance/d.Size {
+		maxDistance = d.Size * (q.Passes + 1)
+	}
+
+	sr := &searcher{Search: q, dial: d}
+	for _, direction := range []byte{'L', 'R'} {
+		for distance := 1; distance <= maxDistance; distance++ {
+			sr.moves = append(sr.moves, Rotation{Direction: direction, Distance: distance})
+		}
+	}
+
+	start := searchState{position: d.Position}
+	find := sr.shortest
+	if q.Lexicographic {
+		find = sr.smallest
+	}
+	path, ok := find(start)
+	if !ok {
+		return nil, ErrNoRotations
+	}
+	return path, nil
+}
@@ -0,0 +1,106 @@
+package day01
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Counts are the running totals of a rotation stream.
+type Counts struct {
+	Rotations int64         `json:"rotations"`
+	Position  int           `json:"position"`
+	Part1     solver.Answer `json:"part1"`
+	Part2     solver.Answer `json:"part2"`
+}
+
+// StreamOptions say when Stream reports the running counts.
+type StreamOptions struct {
+	// Every reports after every so many rotations, 0 for never.
+	Every int
+	// Interval reports on a timer, whether or not rotations arrived, 0 for
+	// never.
+	Interval time.Duration
+}
+
+// errStopped ends the reading goroutine once Stream has returned.
+var errStopped = errors.New("stream stopped")
+
+// Stream turns the solver's dial by each rotation as soon as it is read
+// from r, which may never end, and passes the running counts to emit as
+// opts asks, and once more when r or ctx ends if they changed since. A nil
+// emit reports nothing, leaving only the returned counts. Stream returns
+// the final counts at the end of r, on a parse error or when ctx ends,
+// with ctx's error in the last case. A read blocked on r cannot be
+// interrupted, so the goroutine reading it only exits once that read
+// returns.
+func (s Solver) Stream(ctx context.Context, r io.Reader, opts StreamOptions, emit func(Counts)) (Counts, error) {
+	d, err := s.dial()
+	if err != nil {
+		return Counts{}, err
+	}
+
+	rotations := make(chan Rotation)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		readErr <- input.Each(r, func(sec input.Section) error {
+			rot, ok, err := parseRotation(sec, 0)
+			if err != nil || !ok {
+				return err
+			}
+			select {
+			case rotations <- rot:
+				return nil
+			case <-done:
+				return errStopped
+			}
+		})
+	}()
+
+	var tick <-chan time.Time
+	if opts.Interval > 0 {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var n, emitted int64
+	counts := func() Counts {
+		return Counts{Rotations: n, Position: d.Position, Part1: d.TotalStops(), Part2: d.TotalPasses()}
+	}
+	report := func() {
+		emitted = n
+		if emit != nil {
+			emit(counts())
+		}
+	}
+
+	for {
+		select {
+		case rot := <-rotations:
+			d.Rotate(rot)
+			n++
+			if opts.Every > 0 && n%int64(opts.Every) == 0 {
+				report()
+			}
+		case <-tick:
+			report()
+		case err := <-readErr:
+			if n != emitted {
+				report()
+			}
+			return counts(), err
+		case <-ctx.Done():
+			if n != emitted {
+				report()
+			}
+			return counts(), ctx.Err()
+		}
+	}
+}
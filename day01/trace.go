@@ -0,0 +1,117 @@
+package day01
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// traceRecord is the exported form of a step.
+type traceRecord struct {
+	N         int           `json:"n"`
+	Direction string        `json:"direction"`
+	Distance  int           `json:"distance"`
+	Start     int           `json:"start"`
+	End       int           `json:"end"`
+	Crossings solver.Answer `json:"crossings"`
+	Stop      bool          `json:"stop"`
+}
+
+func record(s Step) traceRecord {
+	return traceRecord{
+		N:         s.N,
+		Direction: string(s.Direction),
+		Distance:  s.Distance,
+		Start:     s.Start,
+		End:       s.End,
+		Crossings: s.Crossings,
+		Stop:      s.Stop,
+	}
+}
+
+// WriteTraceJSON writes the steps as a JSON array.
+func WriteTraceJSON(w io.Writer, steps []Step) error {
+	records := make([]traceRecord, len(steps))
+	for i, s := range steps {
+		records[i] = record(s)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// WriteTraceCSV writes the steps as CSV with a header row.
+func WriteTraceCSV(w io.Writer, steps []Step) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"n", "direction", "distance", "start", "end", "crossings", "stop"})
+	for _, s := range steps {
+		cw.Write([]string{
+			strconv.Itoa(s.N),
+			string(s.Direction),
+			strconv.Itoa(s.Distance),
+			strconv.Itoa(s.Start),
+			strconv.Itoa(s.End),
+			s.Crossings.String(),
+			strconv.FormatBool(s.Stop),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Frame size of the dial drawings; columns are doubled because terminal
+// cells are about twice as tall as they are wide.
+const (
+	frameRows = 13
+	frameCols = 27
+)
+
+// Frame draws the dial as ASCII art: a ring of '.' with the targets as
+// '+' and the pointer as '@', 0 at the top and R turning clockwise. Large
+// dials are drawn with fewer ring marks than they have.
+func Frame(size int, targets []int, position int) []string {
+	canvas := make([][]byte, frameRows)
+	for i := range canvas {
+		canvas[i] = []byte(strings.Repeat(" ", frameCols))
+	}
+	plot := func(mark int, c byte) {
+		angle := 2 * math.Pi * float64(mark) / float64(size)
+		row := int(math.Round(float64(frameRows-1) / 2 * (1 - math.Cos(angle))))
+		col := int(math.Round(float64(frameCols-1) / 2 * (1 + math.Sin(angle))))
+		canvas[row][col] = c
+	}
+
+	ring := min(size, 60)
+	for i := 0; i < ring; i++ {
+		plot(i*size/ring, '.')
+	}
+	for _, t := range targets {
+		plot(t, '+')
+	}
+	plot(position, '@')
+
+	// The position goes in the middle.
+	label := []byte(strconv.Itoa(position))
+	copy(canvas[frameRows/2][(frameCols-len(label))/2:], label)
+
+	lines := make([]string, frameRows)
+	for i, row := range canvas {
+		lines[i] = strings.TrimRight(string(row), " ")
+	}
+	return lines
+}
+
+// Caption describes a step in one line.
+func Caption(s Step) string {
+	text := fmt.Sprintf("#%d %v: %d -> %d, crossed a target %v times", s.N, s.Rotation, s.Start, s.End, s.Crossings)
+	if s.Stop {
+		text += ", stopped on a target"
+	}
+	return text
+}
@@ -1,13 +1,18 @@
-package main
+package day02
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
-	"strings"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
+// Solver solves day 2.
+type Solver struct{}
+
 // isInvalidID checks if a number is made of a sequence repeated twice
 func isInvalidID(n int) bool {
 	s := strconv.Itoa(n)
@@ -57,116 +62,72 @@ func isInvalidIDPart2(n int) bool {
 	return false
 }
 
-func solve(filename string) (int64, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	if !scanner.Scan() {
-		return 0, fmt.Errorf("empty file")
-	}
-
-	line := scanner.Text()
-	ranges := strings.Split(line, ",")
-
-	var sum int64
-
-	for _, r := range ranges {
-		r = strings.TrimSpace(r)
-		if r == "" {
-			continue
-		}
-
-		parts := strings.Split(r, "-")
-		if len(parts) != 2 {
-			return 0, fmt.Errorf("invalid range: %s", r)
-		}
-
-		start, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid start: %s", parts[0])
-		}
+func (s Solver) Part1(r io.Reader) (solver.Answer, error) {
+	return s.Part1Context(context.Background(), r)
+}
 
-		end, err := strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid end: %s", parts[1])
-		}
+func (s Solver) Part2(r io.Reader) (solver.Answer, error) {
+	return s.Part2Context(context.Background(), r)
+}
 
-		// Check each number in the range
-		for i := start; i <= end; i++ {
-			if isInvalidID(int(i)) {
-				sum += i
-			}
-		}
-	}
+func (Solver) Part1Context(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	return sumInvalidIDs(ctx, r, isInvalidID)
+}
 
-	return sum, nil
+func (Solver) Part2Context(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	return sumInvalidIDs(ctx, r, isInvalidIDPart2)
 }
 
-func solvePart2(filename string) (int64, error) {
-	file, err := os.Open(filename)
+// checkEvery is how many IDs are checked between looks at the context.
+const checkEvery = 1 << 16
+
+// sumInvalidIDs adds up every ID in the ranges of r that isInvalid reports.
+// Progress is reported in ranges processed. When ctx ends early the sum of
+// the IDs checked so far comes back in a *solver.PartialError.
+func sumInvalidIDs(ctx context.Context, r io.Reader, isInvalid func(int) bool) (solver.Answer, error) {
+	ranges, err := input.Ranges(r)
 	if err != nil {
-		return 0, err
+		return solver.Answer{}, err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	if !scanner.Scan() {
-		return 0, fmt.Errorf("empty file")
+	if len(ranges) == 0 {
+		return solver.Answer{}, fmt.Errorf("empty file")
 	}
 
-	line := scanner.Text()
-	ranges := strings.Split(line, ",")
-
-	var sum int64
-
-	for _, r := range ranges {
-		r = strings.TrimSpace(r)
-		if r == "" {
-			continue
-		}
-
-		parts := strings.Split(r, "-")
-		if len(parts) != 2 {
-			return 0, fmt.Errorf("invalid range: %s", r)
-		}
-
-		start, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid start: %s", parts[0])
-		}
-
-		end, err := strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid end: %s", parts[1])
-		}
+	var sum solver.Answer
+	total := int64(len(ranges))
 
+	for done, rg := range ranges {
 		// Check each number in the range
-		for i := start; i <= end; i++ {
-			if isInvalidIDPart2(int(i)) {
-				sum += i
+		for i := int64(rg.Start); i <= int64(rg.End); i++ {
+			if (i-int64(rg.Start))%checkEvery == 0 {
+				if err := ctx.Err(); err != nil {
+					return solver.Answer{}, &solver.PartialError{Answer: sum, Done: int64(done), Total: total, Unit: "ranges", Err: err}
+				}
+			}
+			if isInvalid(int(i)) {
+				sum = sum.Add(solver.Int(i))
 			}
 		}
+		solver.ReportProgress(ctx, int64(done)+1, total)
 	}
 
 	return sum, nil
 }
 
-func main() {
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Sum of invalid IDs: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
 
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Sum of invalid IDs: %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 2,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Sum of invalid IDs", "Part 2 - Sum of invalid IDs"},
+	})
 }
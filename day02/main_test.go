@@ -1,6 +1,14 @@
-package main
+package day02
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestIsInvalidID(t *testing.T) {
 	tests := []struct {
@@ -38,8 +46,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := int64(1227775554)
-	if result != expected {
+	expected := solver.Int(1227775554)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -86,8 +94,74 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := int64(4174379265)
-	if result != expected {
+	expected := solver.Int(4174379265)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `11-22,95-115,998-1012,1188511880-1188511890,222220-222224,1698522-1698528,446443-446449,38593856-38593862,565653-565659,824824821-824824827,2121212118-2121212124
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(1227775554)},
+		{2, Solver{}.Part2, solver.Int(4174379265)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for part, solve := range []solver.ContextPartFunc{Solver{}.Part1Context, Solver{}.Part2Context} {
+		if _, err := solve(ctx, strings.NewReader(example)); !errors.Is(err, context.Canceled) {
+			t.Errorf("Part %d: expected context.Canceled, got %v", part+1, err)
+		}
+	}
+}
+
+func TestSolverReportsProgressAndPartialResults(t *testing.T) {
+	var last [2]int64
+	ctx := solver.WithProgress(context.Background(), func(done, total int64) {
+		last = [2]int64{done, total}
+	})
+	_, err := Solver{}.Part2Context(ctx, strings.NewReader(example))
+	if err != nil {
+		t.Fatalf("Error solving: %v", err)
+	}
+	if want := [2]int64{11, 11}; last != want {
+		t.Errorf("Expected the last report to be 11 of 11 ranges, got %v", last)
+	}
+
+	// Stop at the first report; the work done so far comes back.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = solver.WithProgress(ctx, func(done, total int64) { cancel() })
+	_, err = Solver{}.Part2Context(ctx, strings.NewReader(example))
+	var partial *solver.PartialError
+	if !errors.As(err, &partial) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a partial result, got %v", err)
+	}
+	if partial.Done == 0 || partial.Done >= partial.Total || partial.Unit != "ranges" {
+		t.Errorf("Expected some but not all ranges done, got %+v", partial)
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
@@ -1,11 +1,31 @@
-package main
+package day03
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"io"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
+// Solver solves day 3. K is the number of batteries turned on per bank in
+// part 2; zero means 12.
+type Solver struct {
+	K int
+}
+
+// defaultK is the number of batteries the puzzle turns on in part 2.
+const defaultK = 12
+
+// Params lists the number of batteries part 2 turns on.
+func (Solver) Params() []solver.Param {
+	return []solver.Param{{Name: "k", Default: defaultK, Min: 1, Usage: "batteries turned on per bank in part 2"}}
+}
+
+// WithParams returns a solver turning on values["k"] batteries in part 2.
+func (Solver) WithParams(values solver.Values) (solver.Solver, error) {
+	return Solver{K: values["k"]}, nil
+}
+
 // findMaxJoltage finds the maximum joltage for a bank by trying all pairs
 func findMaxJoltage(bank string) int {
 	maxJoltage := 0
@@ -27,14 +47,13 @@ func findMaxJoltage(bank string) int {
 	return maxJoltage
 }
 
-// findMaxJoltagePart2 finds the maximum 12-digit joltage by selecting 12 batteries
+// findMaxJoltageK finds the maximum k-digit joltage by selecting k batteries
 // Strategy: greedily select the largest digits while maintaining order
-func findMaxJoltagePart2(bank string) int64 {
+func findMaxJoltageK(bank string, k int) solver.Answer {
 	n := len(bank)
-	k := 12 // number of batteries to select
 
 	if n < k {
-		return 0
+		return solver.Answer{}
 	}
 
 	// Greedy approach: for each position in the result, pick the largest digit
@@ -60,89 +79,91 @@ func findMaxJoltagePart2(bank string) int64 {
 		startPos = maxPos + 1
 	}
 
-	// Convert result to int64
-	var joltage int64 = 0
+	// Convert result to a number, which grows past int64 for k > 18
+	var joltage solver.Answer
+	ten := solver.Int(10)
 	for _, digit := range result {
-		joltage = joltage*10 + int64(digit-'0')
+		joltage = joltage.Mul(ten).Add(solver.Int(int64(digit - '0')))
 	}
 
 	return joltage
 }
 
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
+// readBanks reads one bank of battery joltage digits per non-blank line.
+func readBanks(r io.Reader) ([]string, error) {
+	sec, err := input.Read(r)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer file.Close()
 
-	totalJoltage := 0
-	bankCount := 0
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
+	var banks []string
+lines:
+	for i, line := range sec.Lines {
 		if len(line) == 0 {
 			continue
 		}
+		for j := 0; j < len(line); j++ {
+			if line[j] < '0' || line[j] > '9' {
+				if err := sec.Errorf(i, j+1, "expected digit, got %q", line[j]); err != nil {
+					return nil, err
+				}
+				continue lines
+			}
+		}
+		banks = append(banks, line)
+	}
 
-		bankCount++
-		maxJoltage := findMaxJoltage(line)
-		totalJoltage += maxJoltage
+	return banks, nil
+}
+
+func (Solver) Part1(r io.Reader) (solver.Answer, error) {
+	var totalJoltage solver.Answer
+	banks, err := readBanks(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	for _, line := range banks {
+		maxJoltage := findMaxJoltage(line)
+		totalJoltage = totalJoltage.Add(solver.Int(maxJoltage))
 	}
 
-	fmt.Printf("Processed %d banks\n", bankCount)
 	return totalJoltage, nil
 }
 
-func solvePart2(filename string) (int64, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
+func (s Solver) Part2(r io.Reader) (solver.Answer, error) {
+	k := s.K
+	if k == 0 {
+		k = defaultK
 	}
-	defer file.Close()
-
-	var totalJoltage int64 = 0
-	bankCount := 0
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
-		}
 
-		bankCount++
-		maxJoltage := findMaxJoltagePart2(line)
-		totalJoltage += maxJoltage
+	var totalJoltage solver.Answer
+	banks, err := readBanks(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	for _, line := range banks {
+		totalJoltage = totalJoltage.Add(findMaxJoltageK(line, k))
 	}
 
-	fmt.Printf("Processed %d banks (Part 2)\n", bankCount)
 	return totalJoltage, nil
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Total output joltage: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Total output joltage: %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 3,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Total output joltage", "Part 2 - Total output joltage"},
+	})
 }
@@ -1,6 +1,12 @@
-package main
+package day03
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestFindMaxJoltage(t *testing.T) {
 	tests := []struct {
@@ -28,13 +34,13 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 357 // 98 + 89 + 78 + 92
-	if result != expected {
+	expected := solver.Int(357) // 98 + 89 + 78 + 92
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
 
-func TestFindMaxJoltagePart2(t *testing.T) {
+func TestFindMaxJoltageK(t *testing.T) {
 	tests := []struct {
 		bank     string
 		expected int64
@@ -46,21 +52,61 @@ func TestFindMaxJoltagePart2(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := findMaxJoltagePart2(tt.bank)
-		if result != tt.expected {
-			t.Errorf("findMaxJoltagePart2(%s) = %d, expected %d", tt.bank, result, tt.expected)
+		result := findMaxJoltageK(tt.bank, 12)
+		if !result.Equal(solver.Int(tt.expected)) {
+			t.Errorf("findMaxJoltageK(%s, 12) = %d, expected %d", tt.bank, result, tt.expected)
 		}
 	}
 }
 
+func TestFindMaxJoltageBeyondInt64(t *testing.T) {
+	bank := "1234567890987654321012"
+	expected, _ := solver.Parse("34567890987654321012")
+	if result := findMaxJoltageK(bank, 20); !result.Equal(expected) {
+		t.Errorf("findMaxJoltageK(%s, 20) = %d, expected %d", bank, result, expected)
+	}
+}
+
 func TestSolvePart2WithTestInput(t *testing.T) {
 	result, err := solvePart2("input_test")
 	if err != nil {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := int64(3121910778619) // 987654321111 + 811111111119 + 434234234278 + 888911112111
-	if result != expected {
+	expected := solver.Int(3121910778619) // 987654321111 + 811111111119 + 434234234278 + 888911112111
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `987654321111111
+811111111111119
+234234234234278
+818181911112111
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(357)},
+		{2, Solver{}.Part2, solver.Int(3121910778619)},
+		{2, Solver{K: 2}.Part2, solver.Int(357)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
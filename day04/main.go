@@ -1,97 +1,54 @@
-package main
+package day04
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-)
+	"io"
 
-// countAdjacentRolls counts how many rolls of paper are adjacent to position (row, col)
-func countAdjacentRolls(grid []string, row, col int) int {
-	count := 0
-	rows := len(grid)
-	cols := len(grid[0])
-
-	// Check all 8 adjacent positions
-	directions := [][2]int{
-		{-1, -1}, {-1, 0}, {-1, 1}, // top-left, top, top-right
-		{0, -1}, {0, 1}, // left, right
-		{1, -1}, {1, 0}, {1, 1}, // bottom-left, bottom, bottom-right
-	}
+	"github.com/abtris/aoc2025/internal/grid"
+	"github.com/abtris/aoc2025/internal/solver"
+)
 
-	for _, dir := range directions {
-		newRow := row + dir[0]
-		newCol := col + dir[1]
+// Solver solves day 4.
+type Solver struct{}
 
-		// Check bounds
-		if newRow >= 0 && newRow < rows && newCol >= 0 && newCol < cols {
-			if grid[newRow][newCol] == '@' {
-				count++
-			}
+// countAdjacentRolls counts how many rolls of paper are adjacent to p
+func countAdjacentRolls(g *grid.Grid[byte], p grid.Point) int {
+	count := 0
+	for _, c := range g.Neighbors8(p) {
+		if c == '@' {
+			count++
 		}
 	}
-
 	return count
 }
 
-// solve counts how many rolls can be accessed (have < 4 adjacent rolls)
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
-	// Read the grid
-	var grid []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) > 0 {
-			grid = append(grid, line)
+// accessibleRolls finds the rolls with fewer than 4 adjacent rolls
+func accessibleRolls(g *grid.Grid[byte]) []grid.Point {
+	var accessible []grid.Point
+	for p, c := range g.All() {
+		if c == '@' && countAdjacentRolls(g, p) < 4 {
+			accessible = append(accessible, p)
 		}
 	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
-	}
-
-	// Count accessible rolls
-	accessible := 0
-	for row := 0; row < len(grid); row++ {
-		for col := 0; col < len(grid[row]); col++ {
-			if grid[row][col] == '@' {
-				adjacentCount := countAdjacentRolls(grid, row, col)
-				if adjacentCount < 4 {
-					accessible++
-				}
-			}
-		}
-	}
-
-	return accessible, nil
+	return accessible
 }
 
-// solvePart2 iteratively removes accessible rolls until no more can be removed
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
+// Part1 counts how many rolls can be accessed (have < 4 adjacent rolls)
+func (Solver) Part1(r io.Reader) (solver.Answer, error) {
+	// Read the grid
+	g, err := grid.Parse(r, ".@")
 	if err != nil {
-		return 0, err
+		return solver.Answer{}, err
 	}
-	defer file.Close()
 
-	// Read the grid
-	var grid [][]byte
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) > 0 {
-			grid = append(grid, []byte(line))
-		}
-	}
+	return solver.Int(len(accessibleRolls(g))), nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+// Part2 iteratively removes accessible rolls until no more can be removed
+func (Solver) Part2(r io.Reader) (solver.Answer, error) {
+	// Read the grid
+	g, err := grid.Parse(r, ".@")
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	totalRemoved := 0
@@ -99,18 +56,7 @@ func solvePart2(filename string) (int, error) {
 	// Keep removing accessible rolls until no more can be removed
 	for {
 		// Find all accessible rolls in current state
-		var toRemove [][2]int
-
-		for row := 0; row < len(grid); row++ {
-			for col := 0; col < len(grid[row]); col++ {
-				if grid[row][col] == '@' {
-					adjacentCount := countAdjacentRollsBytes(grid, row, col)
-					if adjacentCount < 4 {
-						toRemove = append(toRemove, [2]int{row, col})
-					}
-				}
-			}
-		}
+		toRemove := accessibleRolls(g)
 
 		// If no rolls can be removed, we're done
 		if len(toRemove) == 0 {
@@ -118,58 +64,30 @@ func solvePart2(filename string) (int, error) {
 		}
 
 		// Remove all accessible rolls
-		for _, pos := range toRemove {
-			grid[pos[0]][pos[1]] = '.'
+		for _, p := range toRemove {
+			g.Set(p, '.')
 		}
 
 		totalRemoved += len(toRemove)
 	}
 
-	return totalRemoved, nil
+	return solver.Int(totalRemoved), nil
 }
 
-// countAdjacentRollsBytes is like countAdjacentRolls but works with [][]byte
-func countAdjacentRollsBytes(grid [][]byte, row, col int) int {
-	count := 0
-	rows := len(grid)
-	cols := len(grid[0])
-
-	// Check all 8 adjacent positions
-	directions := [][2]int{
-		{-1, -1}, {-1, 0}, {-1, 1}, // top-left, top, top-right
-		{0, -1}, {0, 1}, // left, right
-		{1, -1}, {1, 0}, {1, 1}, // bottom-left, bottom, bottom-right
-	}
-
-	for _, dir := range directions {
-		newRow := row + dir[0]
-		newCol := col + dir[1]
-
-		// Check bounds
-		if newRow >= 0 && newRow < rows && newCol >= 0 && newCol < cols {
-			if grid[newRow][newCol] == '@' {
-				count++
-			}
-		}
-	}
-
-	return count
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Accessible rolls: %d\n", result)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Total removed rolls: %d\n", result2)
+func init() {
+	solver.Register(solver.Day{
+		Number: 4,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Accessible rolls", "Part 2 - Total removed rolls"},
+	})
 }
@@ -1,6 +1,12 @@
-package main
+package day04
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestSolveWithTestInput(t *testing.T) {
 	result, err := solve("input_test")
@@ -8,8 +14,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 13
-	if result != expected {
+	expected := solver.Int(13)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -20,8 +26,45 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 43 // 13 + 12 + 7 + 5 + 2 + 1 + 1 + 1 + 1
-	if result != expected {
+	expected := solver.Int(43) // 13 + 12 + 7 + 5 + 2 + 1 + 1 + 1 + 1
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `..@@.@@@@.
+@@@.@.@.@@
+@@@@@.@.@@
+@.@@@@..@.
+@@.@@@@.@@
+.@@@@@@@.@
+.@.@.@.@@@
+@.@@@.@@@@
+.@@@@@@@@.
+@.@.@@@.@.
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(13)},
+		{2, Solver{}.Part2, solver.Int(43)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
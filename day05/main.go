@@ -1,13 +1,15 @@
-package main
+package day05
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"io"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
+// Solver solves day 5.
+type Solver struct{}
+
 type Range struct {
 	start int
 	end   int
@@ -23,48 +25,46 @@ func isFresh(id int, ranges []Range) bool {
 	return false
 }
 
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
+// parseDatabase reads the fresh ranges and the available IDs that follow
+// them after a blank line.
+func parseDatabase(r io.Reader) ([]Range, []int, error) {
+	sections, err := input.Sections(r)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
-	defer file.Close()
-
-	var ranges []Range
-	var availableIDs []int
-	parsingRanges := true
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Empty line separates ranges from available IDs
-		if len(line) == 0 {
-			parsingRanges = false
-			continue
+	if len(sections) == 0 {
+		return nil, nil, nil
+	}
+	if len(sections) > 2 {
+		if err := sections[2].Errorf(0, 0, "expected end of input after available IDs"); err != nil {
+			return nil, nil, err
 		}
+	}
 
-		if parsingRanges {
-			// Parse range like "3-5"
-			parts := strings.Split(line, "-")
-			if len(parts) == 2 {
-				start, err1 := strconv.Atoi(parts[0])
-				end, err2 := strconv.Atoi(parts[1])
-				if err1 == nil && err2 == nil {
-					ranges = append(ranges, Range{start: start, end: end})
-				}
-			}
-		} else {
-			// Parse available ID
-			id, err := strconv.Atoi(line)
-			if err == nil {
-				availableIDs = append(availableIDs, id)
-			}
+	parsed, err := sections[0].Ranges()
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]Range, len(parsed))
+	for i, rg := range parsed {
+		ranges[i] = Range{start: rg.Start, end: rg.End}
+	}
+
+	var availableIDs []int
+	if len(sections) == 2 {
+		availableIDs, err = sections[1].Ints()
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	return ranges, availableIDs, nil
+}
+
+func (Solver) Part1(r io.Reader) (solver.Answer, error) {
+	ranges, availableIDs, err := parseDatabase(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	// Count how many available IDs are fresh
@@ -75,41 +75,14 @@ func solve(filename string) (int, error) {
 		}
 	}
 
-	return freshCount, nil
+	return solver.Int(freshCount), nil
 }
 
-// solvePart2 counts total unique IDs covered by all ranges
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
+// Part2 counts total unique IDs covered by all ranges
+func (Solver) Part2(r io.Reader) (solver.Answer, error) {
+	ranges, _, err := parseDatabase(r)
 	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
-	var ranges []Range
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Empty line means we're done with ranges
-		if len(line) == 0 {
-			break
-		}
-
-		// Parse range like "3-5"
-		parts := strings.Split(line, "-")
-		if len(parts) == 2 {
-			start, err1 := strconv.Atoi(parts[0])
-			end, err2 := strconv.Atoi(parts[1])
-			if err1 == nil && err2 == nil {
-				ranges = append(ranges, Range{start: start, end: end})
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
+		return solver.Answer{}, err
 	}
 
 	// Merge overlapping ranges and count total IDs
@@ -117,9 +90,9 @@ func solvePart2(filename string) (int, error) {
 }
 
 // countTotalIDs merges overlapping ranges and counts total unique IDs
-func countTotalIDs(ranges []Range) int {
+func countTotalIDs(ranges []Range) solver.Answer {
 	if len(ranges) == 0 {
-		return 0
+		return solver.Answer{}
 	}
 
 	// Sort ranges by start position
@@ -152,28 +125,28 @@ func countTotalIDs(ranges []Range) int {
 	}
 
 	// Count total IDs in merged ranges
-	total := 0
+	var total solver.Answer
 	for _, r := range merged {
-		total += r.end - r.start + 1
+		total = total.Add(solver.Int(r.end - r.start + 1))
 	}
 
 	return total
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Fresh ingredient IDs: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Total fresh IDs in ranges: %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 5,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Fresh ingredient IDs", "Part 2 - Total fresh IDs in ranges"},
+	})
 }
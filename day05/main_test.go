@@ -1,6 +1,12 @@
-package main
+package day05
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestIsFresh(t *testing.T) {
 	ranges := []Range{
@@ -36,8 +42,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 3 // IDs 5, 11, 17 are fresh
-	if result != expected {
+	expected := solver.Int(3) // IDs 5, 11, 17 are fresh
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -51,8 +57,8 @@ func TestCountTotalIDs(t *testing.T) {
 	}
 
 	result := countTotalIDs(ranges)
-	expected := 14 // 3,4,5,10,11,12,13,14,15,16,17,18,19,20
-	if result != expected {
+	expected := solver.Int(14) // 3,4,5,10,11,12,13,14,15,16,17,18,19,20
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -63,8 +69,46 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 14
-	if result != expected {
+	expected := solver.Int(14)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `3-5
+10-14
+16-20
+12-18
+
+1
+5
+8
+11
+17
+32
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(3)},
+		{2, Solver{}.Part2, solver.Int(14)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
@@ -1,38 +1,108 @@
-package main
+package day06
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
+// Solver solves day 6.
+type Solver struct{}
+
 type Problem struct {
 	numbers   []int
 	operation string // "*" or "+"
 }
 
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
+// readWorksheet reads the worksheet lines: rows of digits followed by a
+// row of operators, all aligned with spaces. Trailing blank lines are
+// dropped. In lenient mode a problem with a malformed cell is left out by
+// blanking its columns.
+func readWorksheet(r io.Reader) ([]string, error) {
+	sec, err := input.Read(r)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	for len(sec.Lines) > 0 && strings.TrimSpace(sec.Lines[len(sec.Lines)-1]) == "" {
+		sec.Lines = sec.Lines[:len(sec.Lines)-1]
 	}
-	defer file.Close()
 
-	// Read all lines
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	var bad []int
+	for i, line := range sec.Lines {
+		allowed := "0123456789 "
+		if i == len(sec.Lines)-1 {
+			allowed = "*+ "
+		}
+		for j := 0; j < len(line); j++ {
+			if strings.IndexByte(allowed, line[j]) < 0 {
+				if err := sec.Errorf(i, j+1, "expected one of %q, got %q", allowed, line[j]); err != nil {
+					return nil, err
+				}
+				bad = append(bad, j)
+			}
+		}
+	}
+	if len(bad) > 0 {
+		return blankProblems(sec.Lines, bad), nil
+	}
+
+	return sec.Lines, nil
+}
+
+// blankProblems replaces the problems containing the given columns with
+// spaces. A problem spans the columns between two all-space columns.
+func blankProblems(lines []string, cols []int) []string {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+	rows := make([][]byte, len(lines))
+	for i, line := range lines {
+		rows[i] = []byte(line + strings.Repeat(" ", width-len(line)))
+	}
+	empty := func(col int) bool {
+		for _, row := range rows {
+			if row[col] != ' ' {
+				return false
+			}
+		}
+		return true
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	for _, col := range cols {
+		start, end := col, col+1
+		for start > 0 && !empty(start-1) {
+			start--
+		}
+		for end < width && !empty(end) {
+			end++
+		}
+		for _, row := range rows {
+			for j := start; j < end; j++ {
+				row[j] = ' '
+			}
+		}
+	}
+
+	blanked := make([]string, len(rows))
+	for i, row := range rows {
+		blanked[i] = string(row)
+	}
+	return blanked
+}
+
+func (Solver) Part1(r io.Reader) (solver.Answer, error) {
+	// Read all lines
+	lines, err := readWorksheet(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	if len(lines) == 0 {
-		return 0, nil
+		return solver.Answer{}, nil
 	}
 
 	// Find the width of the worksheet
@@ -88,10 +158,10 @@ func solve(filename string) (int, error) {
 	}
 
 	// Calculate grand total
-	grandTotal := 0
+	var grandTotal solver.Answer
 	for _, problem := range problems {
 		result := calculateProblem(problem)
-		grandTotal += result
+		grandTotal = grandTotal.Add(result)
 	}
 
 	return grandTotal, nil
@@ -175,44 +245,33 @@ func getProblemWidth(lines []string, startCol, maxWidth int) int {
 	return width
 }
 
-func calculateProblem(problem Problem) int {
+func calculateProblem(problem Problem) solver.Answer {
 	if len(problem.numbers) == 0 {
-		return 0
+		return solver.Answer{}
 	}
 
-	result := problem.numbers[0]
+	result := solver.Int(problem.numbers[0])
 	for i := 1; i < len(problem.numbers); i++ {
 		if problem.operation == "*" {
-			result *= problem.numbers[i]
+			result = result.Mul(solver.Int(problem.numbers[i]))
 		} else if problem.operation == "+" {
-			result += problem.numbers[i]
+			result = result.Add(solver.Int(problem.numbers[i]))
 		}
 	}
 
 	return result
 }
 
-// solvePart2 reads problems right-to-left with each column being a digit position
-func solvePart2(filename string) (int64, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
+// Part2 reads problems right-to-left with each column being a digit position
+func (Solver) Part2(r io.Reader) (solver.Answer, error) {
 	// Read all lines
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	lines, err := readWorksheet(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	if len(lines) == 0 {
-		return 0, nil
+		return solver.Answer{}, nil
 	}
 
 	// Find the width of the worksheet
@@ -277,10 +336,10 @@ func solvePart2(filename string) (int64, error) {
 	}
 
 	// Calculate grand total
-	var grandTotal int64
+	var grandTotal solver.Answer
 	for _, problem := range problems {
 		result := calculateProblemPart2(problem)
-		grandTotal += result
+		grandTotal = grandTotal.Add(result)
 	}
 
 	return grandTotal, nil
@@ -350,37 +409,26 @@ func extractProblemPart2(lines []string, startCol, endCol int) *Problem {
 	}
 }
 
-func calculateProblemPart2(problem Problem) int64 {
-	if len(problem.numbers) == 0 {
-		return 0
-	}
-
-	result := int64(problem.numbers[0])
-	for i := 1; i < len(problem.numbers); i++ {
-		if problem.operation == "*" {
-			result *= int64(problem.numbers[i])
-		} else if problem.operation == "+" {
-			result += int64(problem.numbers[i])
-		}
-	}
+// calculateProblemPart2 evaluates a problem read right-to-left; the
+// arithmetic is the same as in part 1.
+func calculateProblemPart2(problem Problem) solver.Answer {
+	return calculateProblem(problem)
+}
 
-	return result
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Grand total: %d\n", result)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Grand total: %d\n", result2)
+func init() {
+	solver.Register(solver.Day{
+		Number: 6,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Grand total", "Part 2 - Grand total"},
+	})
 }
@@ -1,6 +1,13 @@
-package main
+package day06
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestCalculateProblem(t *testing.T) {
 	tests := []struct {
@@ -15,7 +22,7 @@ func TestCalculateProblem(t *testing.T) {
 
 	for _, tt := range tests {
 		result := calculateProblem(tt.problem)
-		if result != tt.expected {
+		if !result.Equal(solver.Int(tt.expected)) {
 			t.Errorf("calculateProblem(%v) = %d, expected %d", tt.problem, result, tt.expected)
 		}
 	}
@@ -27,8 +34,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 4277556 // 33210 + 490 + 4243455 + 401
-	if result != expected {
+	expected := solver.Int(4277556) // 33210 + 490 + 4243455 + 401
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -46,7 +53,7 @@ func TestCalculateProblemPart2(t *testing.T) {
 
 	for _, tt := range tests {
 		result := calculateProblemPart2(tt.problem)
-		if result != tt.expected {
+		if !result.Equal(solver.Int(tt.expected)) {
 			t.Errorf("calculateProblemPart2(%v) = %d, expected %d", tt.problem, result, tt.expected)
 		}
 	}
@@ -58,8 +65,75 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := int64(3263827) // 1058 + 3253600 + 625 + 8544
-	if result != expected {
+	expected := solver.Int(3263827) // 1058 + 3253600 + 625 + 8544
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `123 328  51 64
+ 45 64  387 23
+  6 98  215 314
+*   +   *   +
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(4277556)},
+		{2, Solver{}.Part2, solver.Int(3263827)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithTrailingBlankLines(t *testing.T) {
+	for _, input := range []string{example + "\n", example + "   \n\n"} {
+		result, err := Solver{}.Part1(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Error solving %q: %v", input, err)
+		}
+		if !result.Equal(solver.Int(4277556)) {
+			t.Errorf("Expected %d, got %d", 4277556, result)
+		}
+	}
+}
+
+func TestSolverLenient(t *testing.T) {
+	// The bad cell leaves out the second problem, 328 + 64 + 98.
+	bad := strings.Replace(example, " 45 64 ", " 45 6x ", 1)
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(4277556 - 490)},
+		{2, Solver{}.Part2, solver.Int(3263827 - 625)},
+	}
+
+	for _, tt := range tests {
+		src := &input.Source{R: strings.NewReader(bad), Lenient: true}
+		result, err := tt.solve(src)
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) || len(src.Warnings) != 1 {
+			t.Errorf("Part %d: expected %d with one warning, got %d and %v", tt.part, tt.expected, result, src.Warnings)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
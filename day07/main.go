@@ -1,66 +1,51 @@
-package main
+package day07
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-)
-
-type Beam struct {
-	row int
-	col int
-}
-
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
+	"io"
 
-	// Read the grid
-	var grid []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		grid = append(grid, scanner.Text())
-	}
+	"github.com/abtris/aoc2025/internal/grid"
+	"github.com/abtris/aoc2025/internal/solver"
+)
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
-	}
+// Solver solves day 7.
+type Solver struct{}
 
-	if len(grid) == 0 {
-		return 0, nil
+// parseManifold reads the manifold and finds the start S in its first row.
+func parseManifold(r io.Reader) (*grid.Grid[byte], grid.Point, error) {
+	g, err := grid.Parse(r, ".S^")
+	if err != nil || g.Rows() == 0 {
+		return g, grid.Point{}, err
 	}
 
-	// Find the starting position 'S'
-	startCol := -1
-	for col := 0; col < len(grid[0]); col++ {
-		if grid[0][col] == 'S' {
-			startCol = col
-			break
-		}
+	start, ok := grid.Find(g, 'S')
+	if !ok || start.Row != 0 {
+		return nil, grid.Point{}, fmt.Errorf("no starting position found")
 	}
+	return g, start, nil
+}
 
-	if startCol == -1 {
-		return 0, fmt.Errorf("no starting position found")
+func (Solver) Part1(r io.Reader) (solver.Answer, error) {
+	g, start, err := parseManifold(r)
+	if err != nil || g.Rows() == 0 {
+		return solver.Answer{}, err
 	}
 
 	// Simulate the beam splitting
 	splitCount := 0
 
-	// Queue of active beams (row, col)
-	beams := []Beam{{row: 1, col: startCol}} // Start from row 1 (below S)
+	// Queue of active beams, starting from row 1 (below S)
+	beams := []grid.Point{start.Add(grid.Down)}
 
 	// Track which splitters have been hit to avoid counting the same split twice
-	hitSplitters := make(map[Beam]bool)
+	hitSplitters := make(map[grid.Point]bool)
 
 	// Track which beam starting positions we've already processed
-	processedBeams := make(map[Beam]bool)
+	processedBeams := make(map[grid.Point]bool)
 
 	for len(beams) > 0 {
 		// Process all beams at current level
-		var nextBeams []Beam
+		var nextBeams []grid.Point
 
 		for _, beam := range beams {
 			// Skip if we've already processed a beam starting from this position
@@ -69,158 +54,95 @@ func solve(filename string) (int, error) {
 			}
 			processedBeams[beam] = true
 
-			// Move beam downward until it hits a splitter or exits
-			currentRow := beam.row
-			currentCol := beam.col
-
 			// Move down until we hit a splitter or exit
-			for currentRow < len(grid) {
-				if currentCol < 0 || currentCol >= len(grid[currentRow]) {
-					break
+			for p := beam; g.In(p); p = p.Add(grid.Down) {
+				if g.At(p) != '^' {
+					continue
 				}
 
-				if grid[currentRow][currentCol] == '^' {
-					// Check if we've already hit this splitter
-					splitterKey := Beam{row: currentRow, col: currentCol}
-					if !hitSplitters[splitterKey] {
-						// Hit a splitter for the first time - count it
-						splitCount++
-						hitSplitters[splitterKey] = true
-					}
-
-					// Create two new beams (left and right)
-					// Left beam starts at row+1, col-1
-					if currentCol-1 >= 0 {
-						leftBeam := Beam{row: currentRow + 1, col: currentCol - 1}
-						if !processedBeams[leftBeam] {
-							nextBeams = append(nextBeams, leftBeam)
-						}
-					}
+				// Hit a splitter; count it the first time only
+				if !hitSplitters[p] {
+					splitCount++
+					hitSplitters[p] = true
+				}
 
-					// Right beam starts at row+1, col+1
-					if currentCol+1 < len(grid[currentRow]) {
-						rightBeam := Beam{row: currentRow + 1, col: currentCol + 1}
-						if !processedBeams[rightBeam] {
-							nextBeams = append(nextBeams, rightBeam)
-						}
+				// Create two new beams (left and right) on the next row
+				for _, side := range []grid.Point{grid.Left, grid.Right} {
+					next := p.Add(side)
+					if g.In(next) && !processedBeams[next.Add(grid.Down)] {
+						nextBeams = append(nextBeams, next.Add(grid.Down))
 					}
-
-					break
 				}
 
-				currentRow++
+				break
 			}
 		}
 
 		beams = nextBeams
 	}
 
-	return splitCount, nil
+	return solver.Int(splitCount), nil
 }
 
-// solvePart2 counts the number of different timelines (paths) a particle can take
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
-	// Read the grid
-	var grid []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		grid = append(grid, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
-	}
-
-	if len(grid) == 0 {
-		return 0, nil
-	}
-
-	// Find the starting position 'S'
-	startCol := -1
-	for col := 0; col < len(grid[0]); col++ {
-		if grid[0][col] == 'S' {
-			startCol = col
-			break
-		}
-	}
-
-	if startCol == -1 {
-		return 0, fmt.Errorf("no starting position found")
+// Part2 counts the number of different timelines (paths) a particle can take
+func (Solver) Part2(r io.Reader) (solver.Answer, error) {
+	g, start, err := parseManifold(r)
+	if err != nil || g.Rows() == 0 {
+		return solver.Answer{}, err
 	}
 
 	// Count all possible paths using DFS with memoization
 	// Each path represents a timeline
-	memo := make(map[Beam]int)
-	timelineCount := countPathsMemo(grid, 1, startCol, memo)
+	memo := make(map[grid.Point]solver.Answer)
+	timelineCount := countPathsMemo(g, start.Add(grid.Down), memo)
 
 	return timelineCount, nil
 }
 
 // countPathsMemo recursively counts all possible paths from a given position with memoization
-func countPathsMemo(grid []string, row, col int, memo map[Beam]int) int {
+func countPathsMemo(g *grid.Grid[byte], start grid.Point, memo map[grid.Point]solver.Answer) solver.Answer {
 	// Check memo
-	key := Beam{row: row, col: col}
-	if count, found := memo[key]; found {
+	if count, found := memo[start]; found {
 		return count
 	}
 
 	// Move down until we hit a splitter or exit
-	currentRow := row
-	for currentRow < len(grid) {
-		if col < 0 || col >= len(grid[currentRow]) {
-			// Exited the grid - this is one complete path/timeline
-			return 1
+	for p := start; g.In(p); p = p.Add(grid.Down) {
+		if g.At(p) != '^' {
+			continue
 		}
 
-		if grid[currentRow][col] == '^' {
-			// Hit a splitter - particle takes both paths
-			leftPaths := 0
-			rightPaths := 0
-
-			// Left path
-			if col-1 >= 0 {
-				leftPaths = countPathsMemo(grid, currentRow+1, col-1, memo)
-			}
-
-			// Right path
-			if col+1 < len(grid[currentRow]) {
-				rightPaths = countPathsMemo(grid, currentRow+1, col+1, memo)
+		// Hit a splitter - particle takes both paths
+		var result solver.Answer
+		for _, side := range []grid.Point{grid.Left, grid.Right} {
+			if next := p.Add(side); g.In(next) {
+				result = result.Add(countPathsMemo(g, next.Add(grid.Down), memo))
 			}
-
-			// Total timelines is sum of both paths
-			result := leftPaths + rightPaths
-			memo[key] = result
-			return result
 		}
 
-		currentRow++
+		memo[start] = result
+		return result
 	}
 
-	// Exited the bottom of the grid - this is one complete path/timeline
-	memo[key] = 1
-	return 1
+	// Exited the grid - this is one complete path/timeline
+	memo[start] = solver.Int(1)
+	return solver.Int(1)
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Beam splits: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Timelines: %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 7,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Beam splits", "Part 2 - Timelines"},
+	})
 }
@@ -1,6 +1,13 @@
-package main
+package day07
 
-import "testing"
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestSolveWithTestInput(t *testing.T) {
 	result, err := solve("input_test")
@@ -8,8 +15,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 21
-	if result != expected {
+	expected := solver.Int(21)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -20,8 +27,86 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 40
-	if result != expected {
+	expected := solver.Int(40)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `.......S.......
+...............
+.......^.......
+...............
+......^.^......
+...............
+.....^.^.^.....
+...............
+....^.^...^....
+...............
+...^.^...^.^...
+...............
+..^...^.....^..
+...............
+.^.^.^.^.^...^.
+...............
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(21)},
+		{2, Solver{}.Part2, solver.Int(40)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
+
+// pyramid builds a manifold where every beam hits a splitter on each of
+// the given levels, so the number of timelines is 2^levels.
+func pyramid(levels int) string {
+	width := 2*levels + 3
+	center := width / 2
+
+	var b strings.Builder
+	row := []byte(strings.Repeat(".", width))
+	row[center] = 'S'
+	b.Write(row)
+	b.WriteByte('\n')
+	for level := 0; level < levels; level++ {
+		b.WriteString(strings.Repeat(".", width) + "\n")
+		row := []byte(strings.Repeat(".", width))
+		for col := center - level; col <= center+level; col += 2 {
+			row[col] = '^'
+		}
+		b.Write(row)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestSolvePart2PromotesToBigInt(t *testing.T) {
+	result, err := Solver{}.Part2(strings.NewReader(pyramid(70)))
+	if err != nil {
+		t.Fatalf("Error solving part 2: %v", err)
+	}
+
+	expected := new(big.Int).Lsh(big.NewInt(1), 70)
+	if !result.IsBig() || result.Big().Cmp(expected) != 0 {
+		t.Errorf("Expected %s, got %s", expected, result)
+	}
+}
@@ -1,22 +1,38 @@
-package main
+package day08
 
 import (
-	"bufio"
 	"fmt"
-	"math"
-	"os"
+	"io"
 	"sort"
-	"strconv"
-	"strings"
+
+	"github.com/abtris/aoc2025/internal/geom"
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
-type Point struct {
-	x, y, z int
+// Solver solves day 8. Connections is the number of closest pairs joined in
+// part 1; zero means 1000.
+type Solver struct {
+	Connections int
+}
+
+// defaultConnections is the number of pairs the puzzle joins in part 1.
+const defaultConnections = 1000
+
+// Params lists the number of connections made in part 1.
+func (Solver) Params() []solver.Param {
+	return []solver.Param{{Name: "connections", Default: defaultConnections, Min: 1, Usage: "closest pairs joined in part 1"}}
+}
+
+// WithParams returns a solver joining values["connections"] pairs in part 1.
+func (Solver) WithParams(values solver.Values) (solver.Solver, error) {
+	return Solver{Connections: values["connections"]}, nil
 }
 
+// Edge joins junction boxes i and j, which are distance² apart.
 type Edge struct {
 	i, j     int
-	distance float64
+	distance int
 }
 
 // UnionFind data structure
@@ -81,43 +97,25 @@ func (uf *UnionFind) GetComponentSizes() []int {
 	return sizes
 }
 
-func distance(p1, p2 Point) float64 {
-	dx := float64(p1.x - p2.x)
-	dy := float64(p1.y - p2.y)
-	dz := float64(p1.z - p2.z)
-	return math.Sqrt(dx*dx + dy*dy + dz*dz)
-}
-
-func solve(filename string, numConnections int) (int, error) {
-	file, err := os.Open(filename)
+// readPoints reads one junction box position per line.
+func readPoints(r io.Reader) ([]geom.Vec3, error) {
+	coords, err := input.Points3D(r)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer file.Close()
 
-	// Read all points
-	var points []Point
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, ",")
-		if len(parts) != 3 {
-			continue
-		}
-
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-		z, _ := strconv.Atoi(parts[2])
-
-		points = append(points, Point{x: x, y: y, z: z})
+	points := make([]geom.Vec3, len(coords))
+	for i, c := range coords {
+		points[i] = geom.Vec3{X: c[0], Y: c[1], Z: c[2]}
 	}
+	return points, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+func (s Solver) Part1(r io.Reader) (solver.Answer, error) {
+	// Read all points
+	points, err := readPoints(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	n := len(points)
@@ -126,7 +124,8 @@ func solve(filename string, numConnections int) (int, error) {
 	var edges []Edge
 	for i := 0; i < n; i++ {
 		for j := i + 1; j < n; j++ {
-			dist := distance(points[i], points[j])
+			// Squared distances sort the same and stay exact
+			dist := points[i].DistSq(points[j])
 			edges = append(edges, Edge{i: i, j: j, distance: dist})
 		}
 	}
@@ -139,8 +138,13 @@ func solve(filename string, numConnections int) (int, error) {
 	// Use Union-Find to connect the closest pairs
 	uf := NewUnionFind(n)
 
-	// Try the numConnections shortest edges
-	for i := 0; i < numConnections && i < len(edges); i++ {
+	connections := s.Connections
+	if connections == 0 {
+		connections = defaultConnections
+	}
+
+	// Try the Connections shortest edges
+	for i := 0; i < connections && i < len(edges); i++ {
 		uf.Union(edges[i].i, edges[i].j)
 	}
 
@@ -151,44 +155,19 @@ func solve(filename string, numConnections int) (int, error) {
 	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
 
 	// Multiply the three largest
-	result := 1
+	result := solver.Int(1)
 	for i := 0; i < 3 && i < len(sizes); i++ {
-		result *= sizes[i]
+		result = result.Mul(solver.Int(sizes[i]))
 	}
 
 	return result, nil
 }
 
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
+func (Solver) Part2(r io.Reader) (solver.Answer, error) {
 	// Read all points
-	var points []Point
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, ",")
-		if len(parts) != 3 {
-			continue
-		}
-
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-		z, _ := strconv.Atoi(parts[2])
-
-		points = append(points, Point{x: x, y: y, z: z})
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	points, err := readPoints(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	n := len(points)
@@ -197,7 +176,8 @@ func solvePart2(filename string) (int, error) {
 	var edges []Edge
 	for i := 0; i < n; i++ {
 		for j := i + 1; j < n; j++ {
-			dist := distance(points[i], points[j])
+			// Squared distances sort the same and stay exact
+			dist := points[i].DistSq(points[j])
 			edges = append(edges, Edge{i: i, j: j, distance: dist})
 		}
 	}
@@ -219,29 +199,29 @@ func solvePart2(filename string) (int, error) {
 			if numComponents == 1 {
 				// This is the last connection needed
 				// Multiply the X coordinates
-				result := points[edge.i].x * points[edge.j].x
+				result := solver.Int(points[edge.i].X).Mul(solver.Int(points[edge.j].X))
 				return result, nil
 			}
 		}
 	}
 
-	return 0, fmt.Errorf("could not connect all junction boxes")
+	return solver.Answer{}, fmt.Errorf("could not connect all junction boxes")
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input", 1000)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Product of three largest circuits: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string, numConnections int) (solver.Answer, error) {
+	return solver.SolveFile(Solver{Connections: numConnections}.Part1, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Product of X coordinates: %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 8,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Product of three largest circuits", "Part 2 - Product of X coordinates"},
+	})
 }
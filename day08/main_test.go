@@ -1,6 +1,12 @@
-package main
+package day08
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestSolveWithTestInput(t *testing.T) {
 	// After making 10 connections, the answer should be 40
@@ -9,8 +15,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 40
-	if result != expected {
+	expected := solver.Int(40)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -23,8 +29,58 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 25272
-	if result != expected {
+	expected := solver.Int(25272)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `162,817,812
+57,618,57
+906,360,560
+592,479,940
+352,342,300
+466,668,158
+542,29,236
+431,825,988
+739,650,466
+52,470,668
+216,146,977
+819,987,18
+117,168,530
+805,96,715
+346,949,466
+970,615,88
+941,993,340
+862,61,35
+984,92,344
+425,690,689
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{Connections: 10}.Part1, solver.Int(40)},
+		{2, Solver{}.Part2, solver.Int(25272)},
+		// Zero connections means the puzzle's 1000, enough to join all 20.
+		{1, Solver{}.Part1, solver.Int(20)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	// The story's example makes 10 connections instead of 1000.
+	storytest.Check(t, Solver{Connections: 10})
+}
@@ -1,68 +1,77 @@
-package main
+package day09
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"context"
+	"io"
+
+	"github.com/abtris/aoc2025/internal/geom"
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
 )
 
-type Point struct {
-	x, y int
+// Solver solves day 9. Step is the spacing of the points part 2 samples along
+// a rectangle's edges; zero means 100.
+type Solver struct {
+	Step int
 }
 
-func abs(n int) int {
-	if n < 0 {
-		return -n
-	}
-	return n
+// defaultStep is the sampling step of the zero Solver and the parameter.
+const defaultStep = 100
+
+// Params lists the sampling step of part 2.
+func (Solver) Params() []solver.Param {
+	return []solver.Param{{Name: "step", Default: defaultStep, Min: 1, Usage: "spacing of edge samples in part 2"}}
+}
+
+// WithParams returns a solver sampling every values["step"] units in part 2.
+func (Solver) WithParams(values solver.Values) (solver.Solver, error) {
+	return Solver{Step: values["step"]}, nil
 }
 
-func solve(filename string) (int, error) {
-	file, err := os.Open(filename)
+// readTiles reads the red tile positions in order.
+func readTiles(r io.Reader) ([]geom.Vec2, error) {
+	coords, err := input.Points2D(r)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer file.Close()
-
-	// Read all red tile positions
-	var tiles []Point
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
 
-		parts := strings.Split(line, ",")
-		if len(parts) != 2 {
-			continue
-		}
+	points := make([]geom.Vec2, len(coords))
+	for i, c := range coords {
+		points[i] = geom.Vec2{X: c[0], Y: c[1]}
+	}
+	return points, nil
+}
 
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
+func (s Solver) Part1(r io.Reader) (solver.Answer, error) {
+	return s.Part1Context(context.Background(), r)
+}
 
-		tiles = append(tiles, Point{x: x, y: y})
-	}
+func (s Solver) Part2(r io.Reader) (solver.Answer, error) {
+	return s.Part2Context(context.Background(), r)
+}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+func (Solver) Part1Context(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	// Read all red tile positions
+	tiles, err := readTiles(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	// Find the largest rectangle by checking all pairs
-	maxArea := 0
+	var maxArea solver.Answer
 	n := len(tiles)
 
 	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return solver.Answer{}, err
+		}
 		for j := i + 1; j < n; j++ {
 			// Calculate area of rectangle with tiles[i] and tiles[j] as opposite corners
 			// Add 1 to include both endpoints
-			width := abs(tiles[i].x-tiles[j].x) + 1
-			height := abs(tiles[i].y-tiles[j].y) + 1
-			area := width * height
+			rect := geom.RectFrom(tiles[i], tiles[j])
+			area := solver.Int(rect.Width()).Mul(solver.Int(rect.Height()))
 
-			if area > maxArea {
+			if area.Cmp(maxArea) > 0 {
 				maxArea = area
 			}
 		}
@@ -71,22 +80,10 @@ func solve(filename string) (int, error) {
 	return maxArea, nil
 }
 
-// isGreenOrRed checks if a point is red or green
-func isGreenOrRed(p Point, tiles []Point, greenTiles map[Point]bool) bool {
-	// Check if it's a red tile
-	for _, tile := range tiles {
-		if tile.x == p.x && tile.y == p.y {
-			return true
-		}
-	}
-	// Check if it's a green tile
-	return greenTiles[p]
-}
-
 // buildGreenTiles creates a map of green tiles on the edges only
 // We don't precompute interior points due to large coordinate space
-func buildGreenTiles(tiles []Point) map[Point]bool {
-	greenTiles := make(map[Point]bool)
+func buildGreenTiles(tiles []geom.Vec2) map[geom.Vec2]bool {
+	greenTiles := make(map[geom.Vec2]bool)
 	n := len(tiles)
 
 	// Add green tiles on edges between consecutive red tiles
@@ -96,105 +93,68 @@ func buildGreenTiles(tiles []Point) map[Point]bool {
 		p2 := tiles[next]
 
 		// Add all tiles on the line between p1 and p2
-		if p1.x == p2.x {
-			// Vertical line
-			minY := p1.y
-			maxY := p2.y
-			if minY > maxY {
-				minY, maxY = maxY, minY
-			}
-			for y := minY; y <= maxY; y++ {
-				greenTiles[Point{x: p1.x, y: y}] = true
-			}
-		} else if p1.y == p2.y {
-			// Horizontal line
-			minX := p1.x
-			maxX := p2.x
-			if minX > maxX {
-				minX, maxX = maxX, minX
-			}
-			for x := minX; x <= maxX; x++ {
-				greenTiles[Point{x: x, y: p1.y}] = true
+		if p1.X == p2.X || p1.Y == p2.Y {
+			// Vertical or horizontal line
+			line := geom.RectFrom(p1, p2)
+			for x := line.Min.X; x <= line.Max.X; x++ {
+				for y := line.Min.Y; y <= line.Max.Y; y++ {
+					greenTiles[geom.Vec2{X: x, Y: y}] = true
+				}
 			}
 		}
 	}
 
 	// Note: We don't precompute interior points because the coordinate space is too large
-	// Interior points will be checked using isInsidePolygon() on demand
+	// Interior points will be checked using geom.Polygon.Contains on demand
 
 	return greenTiles
 }
 
-// isInsidePolygon uses ray casting algorithm
-func isInsidePolygon(p Point, polygon []Point) bool {
-	n := len(polygon)
-	inside := false
-
-	for i := 0; i < n; i++ {
-		j := (i + 1) % n
-		xi, yi := polygon[i].x, polygon[i].y
-		xj, yj := polygon[j].x, polygon[j].y
-
-		if ((yi > p.y) != (yj > p.y)) &&
-			(p.x < (xj-xi)*(p.y-yi)/(yj-yi)+xi) {
-			inside = !inside
-		}
-	}
-
-	return inside
-}
-
 // isRectangleValid checks if a rectangle only contains red or green tiles
-// by sampling points along its perimeter and checking a few interior points
-func isRectangleValid(minX, maxX, minY, maxY int, polygon []Point, greenTiles map[Point]bool, redTiles map[Point]bool) bool {
-	// Check corners
-	corners := []Point{
-		{x: minX, y: minY},
-		{x: maxX, y: minY},
-		{x: minX, y: maxY},
-		{x: maxX, y: maxY},
+// by sampling points every step units along its perimeter and checking a few
+// interior points
+func isRectangleValid(rect geom.Rect, step int, polygon geom.Polygon, greenTiles map[geom.Vec2]bool, redTiles map[geom.Vec2]bool) bool {
+	// The tile maps cover the boundary; anything else must lie inside
+	allowed := func(p geom.Vec2) bool {
+		return redTiles[p] || greenTiles[p] || polygon.Contains(p)
 	}
 
-	for _, p := range corners {
-		if !redTiles[p] && !greenTiles[p] && !isInsidePolygon(p, polygon) {
+	// Check corners
+	for _, p := range rect.Corners() {
+		if !allowed(p) {
 			return false
 		}
 	}
 
-	// Sample points along the edges (every 100 units to keep it fast)
-	step := 100
-
+	// Sample points along the edges (sparsely to keep it fast)
 	// Top and bottom edges
-	for x := minX; x <= maxX; x += step {
-		for _, y := range []int{minY, maxY} {
-			p := Point{x: x, y: y}
-			if !redTiles[p] && !greenTiles[p] && !isInsidePolygon(p, polygon) {
+	for x := rect.Min.X; x <= rect.Max.X; x += step {
+		for _, y := range []int{rect.Min.Y, rect.Max.Y} {
+			if !allowed(geom.Vec2{X: x, Y: y}) {
 				return false
 			}
 		}
 	}
 
 	// Left and right edges
-	for y := minY; y <= maxY; y += step {
-		for _, x := range []int{minX, maxX} {
-			p := Point{x: x, y: y}
-			if !redTiles[p] && !greenTiles[p] && !isInsidePolygon(p, polygon) {
+	for y := rect.Min.Y; y <= rect.Max.Y; y += step {
+		for _, x := range []int{rect.Min.X, rect.Max.X} {
+			if !allowed(geom.Vec2{X: x, Y: y}) {
 				return false
 			}
 		}
 	}
 
 	// Sample a few interior points
-	midX := (minX + maxX) / 2
-	midY := (minY + maxY) / 2
-	interiorPoints := []Point{
-		{x: midX, y: midY},
-		{x: (minX + midX) / 2, y: (minY + midY) / 2},
-		{x: (maxX + midX) / 2, y: (maxY + midY) / 2},
+	mid := geom.Vec2{X: (rect.Min.X + rect.Max.X) / 2, Y: (rect.Min.Y + rect.Max.Y) / 2}
+	interiorPoints := []geom.Vec2{
+		mid,
+		{X: (rect.Min.X + mid.X) / 2, Y: (rect.Min.Y + mid.Y) / 2},
+		{X: (rect.Max.X + mid.X) / 2, Y: (rect.Max.Y + mid.Y) / 2},
 	}
 
 	for _, p := range interiorPoints {
-		if !redTiles[p] && !greenTiles[p] && !isInsidePolygon(p, polygon) {
+		if !allowed(p) {
 			return false
 		}
 	}
@@ -202,98 +162,77 @@ func isRectangleValid(minX, maxX, minY, maxY int, polygon []Point, greenTiles ma
 	return true
 }
 
-func solvePart2(filename string) (int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return 0, err
+func (s Solver) Part2Context(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	step := s.Step
+	if step == 0 {
+		step = defaultStep
 	}
-	defer file.Close()
 
 	// Read all red tile positions (in order)
-	var tiles []Point
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, ",")
-		if len(parts) != 2 {
-			continue
-		}
-
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-
-		tiles = append(tiles, Point{x: x, y: y})
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	tiles, err := readTiles(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
 	// Build green tiles map
 	greenTiles := buildGreenTiles(tiles)
 
 	// Create a set of red tiles for faster lookup
-	redTiles := make(map[Point]bool)
+	redTiles := make(map[geom.Vec2]bool)
 	for _, t := range tiles {
 		redTiles[t] = true
 	}
 
 	// Find the largest rectangle that only contains red or green tiles
-	maxArea := 0
+	var maxArea solver.Answer
 	n := len(tiles)
 
+	// Pairs are counted as checked once the outer tile moves on.
+	total := int64(n) * int64(n-1) / 2
+	var checked int64
+
 	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return solver.Answer{}, &solver.PartialError{Answer: maxArea, Done: checked, Total: total, Unit: "pairs", Err: err}
+		}
+		solver.ReportProgress(ctx, checked, total)
+		checked += int64(n - i - 1)
+
 		for j := i + 1; j < n; j++ {
 			// Check if rectangle from tiles[i] to tiles[j] only contains red/green
-			rectMinX := tiles[i].x
-			rectMaxX := tiles[j].x
-			if rectMinX > rectMaxX {
-				rectMinX, rectMaxX = rectMaxX, rectMinX
-			}
-
-			rectMinY := tiles[i].y
-			rectMaxY := tiles[j].y
-			if rectMinY > rectMaxY {
-				rectMinY, rectMaxY = rectMaxY, rectMinY
-			}
-
-			width := rectMaxX - rectMinX + 1
-			height := rectMaxY - rectMinY + 1
-			area := width * height
+			rect := geom.RectFrom(tiles[i], tiles[j])
+			area := solver.Int(rect.Width()).Mul(solver.Int(rect.Height()))
 
 			// Skip if this can't beat the current max
-			if area <= maxArea {
+			if area.Cmp(maxArea) <= 0 {
 				continue
 			}
 
 			// Check if rectangle is valid
-			if isRectangleValid(rectMinX, rectMaxX, rectMinY, rectMaxY, tiles, greenTiles, redTiles) {
+			if isRectangleValid(rect, step, tiles, greenTiles, redTiles) {
 				maxArea = area
 			}
 		}
 	}
+	solver.ReportProgress(ctx, total, total)
 
 	return maxArea, nil
 }
 
-func main() {
-	// Part 1
-	result, err := solve("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 1): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1 - Largest rectangle area: %d\n", result)
+// solve runs Part1 against the named input file.
+func solve(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part1, filename)
+}
 
-	// Part 2
-	result2, err := solvePart2("input")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error (Part 2): %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 2 - Largest rectangle area (red/green only): %d\n", result2)
+// solvePart2 runs Part2 against the named input file.
+func solvePart2(filename string) (solver.Answer, error) {
+	return solver.SolveFile(Solver{}.Part2, filename)
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number: 9,
+		Solver: Solver{},
+		Labels: [2]string{"Part 1 - Largest rectangle area", "Part 2 - Largest rectangle area (red/green only)"},
+	})
 }
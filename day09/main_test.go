@@ -1,6 +1,14 @@
-package main
+package day09
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story/storytest"
+)
 
 func TestSolveWithTestInput(t *testing.T) {
 	result, err := solve("input_test")
@@ -8,8 +16,8 @@ func TestSolveWithTestInput(t *testing.T) {
 		t.Fatalf("Error solving: %v", err)
 	}
 
-	expected := 50
-	if result != expected {
+	expected := solver.Int(50)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
@@ -20,8 +28,82 @@ func TestSolvePart2WithTestInput(t *testing.T) {
 		t.Fatalf("Error solving part 2: %v", err)
 	}
 
-	expected := 24
-	if result != expected {
+	expected := solver.Int(24)
+	if !result.Equal(expected) {
 		t.Errorf("Expected %d, got %d", expected, result)
 	}
 }
+
+const example = `7,1
+11,1
+11,7
+9,7
+9,5
+2,5
+2,3
+7,3
+`
+
+func TestSolverWithInlineInput(t *testing.T) {
+	tests := []struct {
+		part     int
+		solve    solver.PartFunc
+		expected solver.Answer
+	}{
+		{1, Solver{}.Part1, solver.Int(50)},
+		{2, Solver{}.Part2, solver.Int(24)},
+		{2, Solver{Step: 1}.Part2, solver.Int(24)},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.solve(strings.NewReader(example))
+		if err != nil {
+			t.Fatalf("Error solving part %d: %v", tt.part, err)
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("Part %d: expected %d, got %d", tt.part, tt.expected, result)
+		}
+	}
+}
+
+func TestSolverHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for part, solve := range []solver.ContextPartFunc{Solver{}.Part1Context, Solver{}.Part2Context} {
+		if _, err := solve(ctx, strings.NewReader(example)); !errors.Is(err, context.Canceled) {
+			t.Errorf("Part %d: expected context.Canceled, got %v", part+1, err)
+		}
+	}
+}
+
+func TestSolverReportsProgressAndPartialResults(t *testing.T) {
+	var last [2]int64
+	ctx := solver.WithProgress(context.Background(), func(done, total int64) {
+		last = [2]int64{done, total}
+	})
+	_, err := Solver{}.Part2Context(ctx, strings.NewReader(example))
+	if err != nil {
+		t.Fatalf("Error solving: %v", err)
+	}
+	if want := [2]int64{28, 28}; last != want {
+		t.Errorf("Expected the last report to be 28 of 28 pairs, got %v", last)
+	}
+
+	// Stop at the first report; the work done so far comes back.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = solver.WithProgress(ctx, func(done, total int64) { cancel() })
+	_, err = Solver{}.Part2Context(ctx, strings.NewReader(example))
+	var partial *solver.PartialError
+	if !errors.As(err, &partial) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a partial result, got %v", err)
+	}
+	if partial.Done == 0 || partial.Done >= partial.Total || partial.Unit != "pairs" {
+		t.Errorf("Expected some but not all pairs done, got %+v", partial)
+	}
+}
+
+func TestSolverWithStoryExample(t *testing.T) {
+	storytest.Check(t, Solver{})
+}
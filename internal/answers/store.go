@@ -0,0 +1,91 @@
+// Package answers stores the accepted answers of every day so refactors can
+// be checked against them.
+package answers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// DefaultFile is the store location relative to the repository root.
+const DefaultFile = "answers.json"
+
+// Inputs lists the input files answers are recorded for.
+var Inputs = []string{"input", "input_test"}
+
+// Entry holds the accepted answers for one input file. A nil part has no
+// accepted answer yet. Params are the solver parameters the input is meant
+// to be solved with, like the smaller connection count of day 8's example.
+type Entry struct {
+	Params solver.Values  `json:"params,omitempty"`
+	Part1  *solver.Answer `json:"part1,omitempty"`
+	Part2  *solver.Answer `json:"part2,omitempty"`
+}
+
+// Store maps a day directory such as "day07" and an input name such as
+// "input_test" to the accepted answers.
+type Store map[string]map[string]Entry
+
+// Load reads a store. A missing file yields an empty store.
+func Load(filename string) (Store, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Store{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	store := Store{}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return store, nil
+}
+
+// Save writes the store as indented JSON.
+func (s Store) Save(filename string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// Lookup returns the accepted answer for a day, input and part.
+func (s Store) Lookup(day int, input string, part int) (solver.Answer, bool) {
+	entry := s[solver.Dir(day)][input]
+	p := entry.Part1
+	if part == 2 {
+		p = entry.Part2
+	}
+	if p == nil {
+		return solver.Answer{}, false
+	}
+	return *p, true
+}
+
+// Params returns the solver parameters recorded for a day and input.
+func (s Store) Params(day int, input string) solver.Values {
+	return s[solver.Dir(day)][input].Params
+}
+
+// Set records the accepted answer for a day, input and part.
+func (s Store) Set(day int, input string, part int, answer solver.Answer) {
+	dir := solver.Dir(day)
+	if s[dir] == nil {
+		s[dir] = make(map[string]Entry)
+	}
+	entry := s[dir][input]
+	if part == 2 {
+		entry.Part2 = &answer
+	} else {
+		entry.Part1 = &answer
+	}
+	s[dir][input] = entry
+}
@@ -0,0 +1,62 @@
+package answers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), DefaultFile)
+
+	store, err := Load(filename)
+	if err != nil {
+		t.Fatalf("Error loading missing store: %v", err)
+	}
+	if len(store) != 0 {
+		t.Errorf("Expected empty store, got %v", store)
+	}
+
+	huge := solver.Int(1 << 62).Mul(solver.Int(8))
+	store.Set(7, "input_test", 2, solver.Int(40))
+	store.Set(7, "input", 2, huge)
+	store["day07"]["input_test"] = Entry{Params: solver.Values{"beams": 2}, Part2: store["day07"]["input_test"].Part2}
+	if err := store.Save(filename); err != nil {
+		t.Fatalf("Error saving: %v", err)
+	}
+
+	loaded, err := Load(filename)
+	if err != nil {
+		t.Fatalf("Error loading: %v", err)
+	}
+	if got, ok := loaded.Lookup(7, "input_test", 2); !ok || !got.Equal(solver.Int(40)) {
+		t.Errorf("Expected 40, got %v (found %v)", got, ok)
+	}
+	if got, ok := loaded.Lookup(7, "input", 2); !ok || !got.Equal(huge) {
+		t.Errorf("Expected %v, got %v (found %v)", huge, got, ok)
+	}
+	if got := loaded.Params(7, "input_test"); got["beams"] != 2 || len(got) != 1 {
+		t.Errorf("Expected beams=2, got %v", got)
+	}
+	if got := loaded.Params(7, "input"); got != nil {
+		t.Errorf("Expected no parameters, got %v", got)
+	}
+	if _, ok := loaded.Lookup(7, "input", 1); ok {
+		t.Error("Expected part 1 to be missing")
+	}
+	if _, ok := loaded.Lookup(8, "input", 1); ok {
+		t.Error("Expected day 8 to be missing")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(filename, []byte(`{"day01": {"input": {"part1": "x"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(filename); err == nil {
+		t.Error("Expected error for malformed answer")
+	}
+}
@@ -0,0 +1,337 @@
+// Package config loads aoc.toml or aoc.json, which hold flag defaults for
+// the aoc commands and parameter values for the solvers:
+//
+//	[run]
+//	format = "json"
+//	j = 4
+//
+//	[day08]
+//	connections = 10
+//
+// The JSON form nests the same tables in one object. Only the subset of
+// TOML needed for this is understood: tables, comments and string,
+// integer and boolean values.
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Names of the config files looked for in each directory.
+const (
+	TOMLFile = "aoc.toml"
+	JSONFile = "aoc.json"
+)
+
+// Config holds command flag defaults and solver parameters.
+type Config struct {
+	// Commands maps a command name to its flag values, e.g.
+	// Commands["run"]["format"] = "json".
+	Commands map[string]map[string]string
+	// Days maps a day number to its solver parameters.
+	Days map[int]solver.Values
+}
+
+// New returns an empty config.
+func New() *Config {
+	return &Config{Commands: map[string]map[string]string{}, Days: map[int]solver.Values{}}
+}
+
+var (
+	dayRe = regexp.MustCompile(`^day(\d\d)$`)
+	keyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// table makes sure the named table exists, so that even an empty table
+// of an unknown command is noticed.
+func (c *Config) table(name string) error {
+	if m := dayRe.FindStringSubmatch(name); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		if c.Days[day] == nil {
+			c.Days[day] = solver.Values{}
+		}
+		return nil
+	}
+	if !keyRe.MatchString(name) {
+		return fmt.Errorf("invalid table name %q", name)
+	}
+	if c.Commands[name] == nil {
+		c.Commands[name] = map[string]string{}
+	}
+	return nil
+}
+
+// set records value under the table and key, converting it as the table
+// requires.
+func (c *Config) set(table, key string, value any) error {
+	if err := c.table(table); err != nil {
+		return err
+	}
+	if !keyRe.MatchString(key) {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	if m := dayRe.FindStringSubmatch(table); m != nil {
+		n, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("%s.%s must be an integer, got %v", table, key, value)
+		}
+		day, _ := strconv.Atoi(m[1])
+		c.Days[day][key] = n
+		return nil
+	}
+	c.Commands[table][key] = fmt.Sprint(value)
+	return nil
+}
+
+// ParseTOML reads a config in TOML form. Name is used in errors.
+func ParseTOML(r io.Reader, name string) (*Config, error) {
+	c := New()
+	table := ""
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		fail := func(format string, args ...any) error {
+			return &input.ParseError{Name: name, Line: n, Msg: fmt.Sprintf(format, args...)}
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fail("unterminated table header %q", line)
+			}
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			if err := c.table(table); err != nil {
+				return nil, fail("%v", err)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fail("expected key = value, got %q", line)
+		}
+		if table == "" {
+			return nil, fail("key %q outside a table", strings.TrimSpace(key))
+		}
+		value, err := parseValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		if err := c.set(table, strings.TrimSpace(key), value); err != nil {
+			return nil, fail("%v", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// stripComment drops a # comment that is not inside a string.
+func stripComment(line string) string {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case '#':
+			if !quoted {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// parseValue parses a TOML string, integer or boolean.
+func parseValue(s string) (any, error) {
+	switch {
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", s)
+		}
+		return v, nil
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(s, "_", ""))
+	if err != nil {
+		return nil, fmt.Errorf("expected string, integer or boolean, got %q", s)
+	}
+	return n, nil
+}
+
+// ParseJSON reads a config in JSON form. Name is used in errors.
+func ParseJSON(r io.Reader, name string) (*Config, error) {
+	var tables map[string]map[string]any
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&tables); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	c := New()
+	for table, values := range tables {
+		if err := c.table(table); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for key, value := range values {
+			switch v := value.(type) {
+			case json.Number:
+				n, err := strconv.Atoi(v.String())
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s.%s: expected integer, got %s", name, table, key, v)
+				}
+				value = n
+			case string, bool:
+			default:
+				return nil, fmt.Errorf("%s: %s.%s: expected string, integer or boolean, got %v", name, table, key, v)
+			}
+			if err := c.set(table, key, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// Load reads the config file, choosing the format by its extension.
+func Load(filename string) (*Config, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if filepath.Ext(filename) == ".json" {
+		return ParseJSON(f, filename)
+	}
+	return ParseTOML(f, filename)
+}
+
+// Find loads aoc.toml or aoc.json from each directory in turn, later
+// directories overriding earlier ones, and returns the merged config with
+// the files it read. A directory holding both files is an error.
+func Find(dirs ...string) (*Config, []string, error) {
+	c := New()
+	var files []string
+	for _, dir := range dirs {
+		var found []string
+		for _, name := range []string{TOMLFile, JSONFile} {
+			filename := filepath.Join(dir, name)
+			if _, err := os.Stat(filename); err == nil {
+				found = append(found, filename)
+			}
+		}
+		if len(found) > 1 {
+			return nil, nil, fmt.Errorf("both %s and %s exist; keep one", found[0], found[1])
+		}
+		for _, filename := range found {
+			loaded, err := Load(filename)
+			if err != nil {
+				return nil, nil, err
+			}
+			c.Merge(loaded)
+			files = append(files, filename)
+		}
+	}
+	return c, files, nil
+}
+
+// Dirs returns the directories Find searches by default: the user's config
+// directory, then the current directory.
+func Dirs() []string {
+	var dirs []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "aoc"))
+	}
+	return append(dirs, ".")
+}
+
+// Merge copies every value of o into c, replacing those already set.
+func (c *Config) Merge(o *Config) {
+	for table, values := range o.Commands {
+		c.table(table)
+		for key, value := range values {
+			c.set(table, key, value)
+		}
+	}
+	for day, values := range o.Days {
+		if c.Days[day] == nil {
+			c.Days[day] = solver.Values{}
+		}
+		for key, value := range values {
+			c.Days[day][key] = value
+		}
+	}
+}
+
+// Flags returns the command's values as flag arguments, sorted by name,
+// to be placed before the arguments given on the command line.
+func (c *Config) Flags(command string) []string {
+	values := c.Commands[command]
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]string, len(names))
+	for i, name := range names {
+		args[i] = "--" + name + "=" + values[name]
+	}
+	return args
+}
+
+// Sets returns the solver parameters as "dayNN.name=value" strings, sorted,
+// in the form ParseSet accepts.
+func (c *Config) Sets() []string {
+	var sets []string
+	for day, values := range c.Days {
+		for name, value := range values {
+			sets = append(sets, fmt.Sprintf("day%02d.%s=%d", day, name, value))
+		}
+	}
+	sort.Strings(sets)
+	return sets
+}
+
+// ParseSet parses a "day08.connections=10" override.
+func ParseSet(s string) (day int, name string, value int, err error) {
+	key, raw, ok := strings.Cut(s, "=")
+	if !ok {
+		return 0, "", 0, fmt.Errorf("expected dayNN.name=value, got %q", s)
+	}
+	table, name, ok := strings.Cut(key, ".")
+	m := dayRe.FindStringSubmatch(table)
+	if !ok || m == nil || !keyRe.MatchString(name) {
+		return 0, "", 0, fmt.Errorf("expected dayNN.name=value, got %q", s)
+	}
+	day, _ = strconv.Atoi(m[1])
+	value, err = strconv.Atoi(raw)
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("%s must be an integer, got %q", key, raw)
+	}
+	return day, name, value, nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+const sampleTOML = `# defaults for aoc
+[run]
+format = "json" # trailing comment
+j = 4
+progress = true
+label = "a # b"
+
+[day08]
+connections = 1_000
+
+[day01]
+start = 0
+size = 10
+`
+
+func TestParseTOML(t *testing.T) {
+	c, err := ParseTOML(strings.NewReader(sampleTOML), TOMLFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFlags := []string{"--format=json", "--j=4", "--label=a # b", "--progress=true"}
+	if got := c.Flags("run"); !reflect.DeepEqual(got, expectedFlags) {
+		t.Errorf("Expected flags %q, got %q", expectedFlags, got)
+	}
+	if got := c.Flags("bench"); len(got) != 0 {
+		t.Errorf("Expected no bench flags, got %q", got)
+	}
+
+	expectedSets := []string{"day01.size=10", "day01.start=0", "day08.connections=1000"}
+	if got := c.Sets(); !reflect.DeepEqual(got, expectedSets) {
+		t.Errorf("Expected sets %q, got %q", expectedSets, got)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x = 1\n", `aoc.toml:1: key "x" outside a table`},
+		{"[run\n", `aoc.toml:1: unterminated table header "[run"`},
+		{"[run]\nformat\n", `aoc.toml:2: expected key = value, got "format"`},
+		{"[run]\nj = four\n", `aoc.toml:2: expected string, integer or boolean, got "four"`},
+		{"[day08]\nconnections = \"ten\"\n", "aoc.toml:2: day08.connections must be an integer, got ten"},
+		{"[run]\n\"bad key\" = 1\n", `aoc.toml:2: invalid key "\"bad key\""`},
+		{"[my table]\n", `aoc.toml:1: invalid table name "my table"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseTOML(strings.NewReader(tt.input), TOMLFile)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("ParseTOML(%q): expected error %q, got %v", tt.input, tt.expected, err)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	c, err := ParseJSON(strings.NewReader(`{"run": {"format": "csv", "j": 2}, "day03": {"k": 2}}`), JSONFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Flags("run"); !reflect.DeepEqual(got, []string{"--format=csv", "--j=2"}) {
+		t.Errorf("Unexpected flags %q", got)
+	}
+	if !reflect.DeepEqual(c.Days[3], solver.Values{"k": 2}) {
+		t.Errorf("Unexpected day 3 values %v", c.Days[3])
+	}
+
+	for _, bad := range []string{`{"day03": {"k": 1.5}}`, `{"run": {"j": [1]}}`, `[]`} {
+		if _, err := ParseJSON(strings.NewReader(bad), JSONFile); err == nil {
+			t.Errorf("ParseJSON(%s): expected error", bad)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	user, project := t.TempDir(), t.TempDir()
+	os.WriteFile(filepath.Join(user, TOMLFile), []byte("[run]\nformat = \"csv\"\nj = 2\n[day08]\nconnections = 5\n"), 0o644)
+	os.WriteFile(filepath.Join(project, JSONFile), []byte(`{"run": {"format": "json"}}`), 0o644)
+
+	c, files, err := Find(user, project, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("Expected two files, got %q", files)
+	}
+	if got := c.Flags("run"); !reflect.DeepEqual(got, []string{"--format=json", "--j=2"}) {
+		t.Errorf("Expected project to override user config, got %q", got)
+	}
+	if c.Days[8]["connections"] != 5 {
+		t.Errorf("Expected 5 connections, got %v", c.Days[8])
+	}
+
+	os.WriteFile(filepath.Join(project, TOMLFile), []byte("[run]\n"), 0o644)
+	if _, _, err := Find(project); err == nil {
+		t.Error("Expected error with both aoc.toml and aoc.json")
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	day, name, value, err := ParseSet("day08.connections=10")
+	if err != nil || day != 8 || name != "connections" || value != 10 {
+		t.Errorf("Expected 8 connections 10, got %d %s %d %v", day, name, value, err)
+	}
+
+	for _, bad := range []string{"day08.connections", "day8.connections=1", "connections=1", "day08.connections=x", "day08.=1"} {
+		if _, _, _, err := ParseSet(bad); err == nil {
+			t.Errorf("ParseSet(%q): expected error", bad)
+		}
+	}
+}
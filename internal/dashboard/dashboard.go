@@ -0,0 +1,321 @@
+// Package dashboard is the state and screen of aoc tui: a list of days with
+// their status, the selected day's story and the result of the last run.
+//
+// The whole screen is redrawn with ANSI escapes after every command. On a
+// terminal the commands are single keypresses, decoded by Keys; otherwise
+// every command is a line of input, so sessions can be scripted.
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Help lists the commands.
+const Help = "j/k or a day number: select  p: part  i: input  enter or r: run  n/b: scroll story  q: quit"
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	reverse     = "\x1b[7m"
+	reset       = "\x1b[0m"
+
+	// listWidth is the width of the day list column.
+	listWidth = 34
+	// footerLines are the lines below the panels: separator, three result
+	// lines and the prompt.
+	footerLines = 5
+)
+
+// Runner runs one part of a day against an input file name such as
+// "input_test".
+type Runner func(d solver.Day, part int, input string) report.Result
+
+// Dashboard is the state of the screen.
+type Dashboard struct {
+	Days  []solver.Day
+	Store answers.Store
+	// Timings are the last known time per day and part, from saved
+	// benchmarks and from runs in this session.
+	Timings map[[2]int]time.Duration
+	// LoadStory returns the lines of a day's story.md.
+	LoadStory func(day int) ([]string, error)
+	Run       Runner
+
+	Width, Height int
+	// KeyMode is set when commands are single keypresses. The footer then
+	// lists the keys instead of showing a prompt.
+	KeyMode bool
+
+	Selected int
+	Part     int
+	Input    string
+	// StoryOffset is the first story line shown.
+	StoryOffset int
+	Last        *report.Result
+	Message     string
+}
+
+// New returns a dashboard over days with part 1 of the first day selected.
+func New(days []solver.Day, store answers.Store) *Dashboard {
+	return &Dashboard{
+		Days:    days,
+		Store:   store,
+		Timings: make(map[[2]int]time.Duration),
+		Width:   100,
+		Height:  30,
+		Part:    1,
+		Input:   answers.Inputs[0],
+	}
+}
+
+// Handle applies one command line. It reports false when the user quits.
+func (d *Dashboard) Handle(line string) bool {
+	d.Message = ""
+	cmd := strings.TrimSpace(line)
+	if n, err := strconv.Atoi(cmd); err == nil {
+		for i, day := range d.Days {
+			if day.Number == n {
+				d.selectDay(i)
+				return true
+			}
+		}
+		d.Message = fmt.Sprintf("day %d is not registered", n)
+		return true
+	}
+
+	switch cmd {
+	case "q", "quit", "exit":
+		return false
+	case "j", "down":
+		d.selectDay(min(d.Selected+1, len(d.Days)-1))
+	case "k", "up":
+		d.selectDay(max(d.Selected-1, 0))
+	case "p":
+		d.Part = 3 - d.Part
+	case "i":
+		if d.Input == answers.Inputs[0] {
+			d.Input = answers.Inputs[1]
+		} else {
+			d.Input = answers.Inputs[0]
+		}
+	case "", "r":
+		d.run()
+	case "n":
+		d.StoryOffset += d.panelHeight()
+	case "b":
+		d.StoryOffset = max(d.StoryOffset-d.panelHeight(), 0)
+	case "?", "h", "help":
+		d.Message = Help
+		if d.KeyMode {
+			d.Message = KeyHelp
+		}
+	default:
+		d.Message = fmt.Sprintf("unknown command %q, ? for help", cmd)
+	}
+	return true
+}
+
+func (d *Dashboard) selectDay(i int) {
+	if i != d.Selected {
+		d.Selected = i
+		d.StoryOffset = 0
+		d.Last = nil
+	}
+}
+
+func (d *Dashboard) run() {
+	if len(d.Days) == 0 || d.Run == nil {
+		return
+	}
+	day := d.Days[d.Selected]
+	r := d.Run(day, d.Part, d.Input)
+	d.Last = &r
+	if r.Error == "" && d.Input == answers.Inputs[0] {
+		d.Timings[[2]int{day.Number, d.Part}] = r.Duration
+	}
+}
+
+func (d *Dashboard) panelHeight() int {
+	return max(d.Height-footerLines-1, 1)
+}
+
+// Render draws the whole screen.
+func (d *Dashboard) Render(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+
+	title := fmt.Sprintf("Advent of Code 2025 | part %d | %s", d.Part, d.Input)
+	b.WriteString(strings.TrimRight(fit(title, d.Width), " ") + "\n")
+
+	left := d.dayList()
+	right := d.storyPanel(max(d.Width-listWidth-3, 10))
+	for i := range d.panelHeight() {
+		l, r := "", ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		if i == d.Selected+1 && d.Selected < len(d.Days) {
+			l = reverse + fit(l, listWidth) + reset
+		} else {
+			l = fit(l, listWidth)
+		}
+		b.WriteString(strings.TrimRight(l+" | "+r, " ") + "\n")
+	}
+
+	b.WriteString(strings.Repeat("-", d.Width) + "\n")
+	lines := d.resultLines()
+	if d.KeyMode && lines[2] == "" {
+		lines[2] = KeyHelp
+	}
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(fit(line, d.Width), " ") + "\n")
+	}
+	if !d.KeyMode {
+		b.WriteString("> ")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dayList returns a header and one line per day: the accepted answers as
+// stars and the last timing of each part.
+func (d *Dashboard) dayList() []string {
+	lines := []string{"DAY  SOLVED  PART 1     PART 2"}
+	for _, day := range d.Days {
+		stars := ""
+		for part := 1; part <= 2; part++ {
+			if _, ok := d.Store.Lookup(day.Number, answers.Inputs[0], part); ok {
+				stars += "*"
+			} else {
+				stars += "."
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%02d   %-6s  %-9s  %s", day.Number, stars,
+			formatTiming(d.Timings[[2]int{day.Number, 1}]), formatTiming(d.Timings[[2]int{day.Number, 2}])))
+	}
+	return lines
+}
+
+// storyPanel returns the selected day's story wrapped to width, starting
+// at StoryOffset.
+func (d *Dashboard) storyPanel(width int) []string {
+	if len(d.Days) == 0 || d.LoadStory == nil {
+		return nil
+	}
+	text, err := d.LoadStory(d.Days[d.Selected].Number)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var lines []string
+	for _, line := range text {
+		lines = append(lines, wrap(line, width)...)
+	}
+	if d.StoryOffset >= len(lines) {
+		d.StoryOffset = max(len(lines)-d.panelHeight(), 0)
+	}
+	return lines[d.StoryOffset:]
+}
+
+// resultLines describes the last run and whether it matches the accepted
+// answer.
+func (d *Dashboard) resultLines() []string {
+	lines := make([]string, 3)
+	switch {
+	case len(d.Days) == 0:
+		lines[0] = "no days registered"
+	case d.Last == nil:
+		lines[0] = fmt.Sprintf("day %02d part %d: press enter to run against %s", d.Days[d.Selected].Number, d.Part, d.Input)
+	case d.Last.Error != "":
+		lines[0] = fmt.Sprintf("day %02d part %d on %s: ERROR", d.Last.Day, d.Last.Part, d.Last.Input)
+		lines[1] = d.Last.Error
+	default:
+		lines[0] = fmt.Sprintf("%s: %d", d.Last.Label, d.Last.Answer)
+		lines[1] = fmt.Sprintf("took %v on %s", d.Last.Duration.Round(time.Microsecond), d.Last.Input)
+		if accepted, ok := d.Store.Lookup(d.Last.Day, d.inputName(d.Last.Input), d.Last.Part); ok {
+			if accepted.Equal(*d.Last.Answer) {
+				lines[1] += ", matches the accepted answer"
+			} else {
+				lines[1] += fmt.Sprintf(", accepted answer is %d", accepted)
+			}
+		}
+	}
+	lines[2] = d.Message
+	return lines
+}
+
+// inputName maps a result's input path back to its name in the store.
+func (d *Dashboard) inputName(path string) string {
+	for _, name := range answers.Inputs {
+		if path == name || strings.HasSuffix(path, "/"+name) {
+			return name
+		}
+	}
+	return path
+}
+
+func formatTiming(t time.Duration) string {
+	switch {
+	case t == 0:
+		return "-"
+	case t >= time.Second:
+		return t.Round(10 * time.Millisecond).String()
+	case t >= time.Millisecond:
+		return t.Round(10 * time.Microsecond).String()
+	}
+	return t.Round(time.Microsecond).String()
+}
+
+// fit pads or cuts s to exactly width runes.
+func fit(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width])
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// wrap breaks a line into pieces of at most width runes at spaces.
+func wrap(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+	var out []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				out = append(out, current)
+				current = ""
+			}
+			r := []rune(word)
+			out = append(out, string(r[:width]))
+			word = string(r[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			out = append(out, current)
+			current = word
+		}
+	}
+	if current != "" {
+		out = append(out, current)
+	}
+	return out
+}
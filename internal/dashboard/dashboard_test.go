@@ -0,0 +1,191 @@
+package dashboard
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/answers"
+	"github.com/abtris/aoc2025/internal/report"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+type fixed struct{}
+
+func (fixed) Part1(io.Reader) (solver.Answer, error) { return solver.Int(1), nil }
+func (fixed) Part2(io.Reader) (solver.Answer, error) { return solver.Int(2), nil }
+
+func newTestDashboard() (*Dashboard, *[]string) {
+	days := []solver.Day{
+		{Number: 1, Solver: fixed{}, Labels: [2]string{"Part 1 - One", "Part 2 - Two"}},
+		{Number: 2, Solver: fixed{}, Labels: [2]string{"Part 1 - One", "Part 2 - Two"}},
+	}
+	store := answers.Store{}
+	store.Set(1, "input", 1, solver.Int(1))
+	store.Set(1, "input", 2, solver.Int(3))
+
+	var runs []string
+	d := New(days, store)
+	d.Width, d.Height = 80, 12
+	d.LoadStory = func(day int) ([]string, error) {
+		if day == 2 {
+			return nil, errors.New("no story for day 2")
+		}
+		return []string{"--- Day 1: Test ---", strings.Repeat("word ", 30), "line 3", "line 4", "line 5", "line 6", "line 7", "line 8"}, nil
+	}
+	d.Run = func(day solver.Day, part int, input string) report.Result {
+		runs = append(runs, input)
+		r := report.Result{Day: day.Number, Part: part, Label: day.Labels[part-1], Input: "day01/" + input, Duration: 1500 * time.Microsecond}
+		if input == "input_test" {
+			r.Error = "boom"
+			return r
+		}
+		answer, _ := day.Solver.Part1(nil)
+		if part == 2 {
+			answer, _ = day.Solver.Part2(nil)
+		}
+		r.Answer = &answer
+		return r
+	}
+	return d, &runs
+}
+
+func render(t *testing.T, d *Dashboard) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := d.Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestDashboard(t *testing.T) {
+	d, runs := newTestDashboard()
+
+	screen := render(t, d)
+	for _, want := range []string{clearScreen, "part 1 | input", reverse + "01   **", "02   ..", "| --- Day 1: Test ---", "press enter to run", "> "} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected screen to contain %q, got:\n%s", want, screen)
+		}
+	}
+
+	d.Handle("")
+	screen = render(t, d)
+	for _, want := range []string{"Part 1 - One: 1", "took 1.5ms on day01/input, matches the accepted answer", "01   **      1.5ms"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected screen to contain %q, got:\n%s", want, screen)
+		}
+	}
+
+	d.Handle("p")
+	d.Handle("r")
+	if screen = render(t, d); !strings.Contains(screen, "accepted answer is 3") {
+		t.Errorf("Expected a mismatch with the accepted answer, got:\n%s", screen)
+	}
+
+	d.Handle("i")
+	d.Handle("r")
+	if screen = render(t, d); !strings.Contains(screen, "ERROR") || !strings.Contains(screen, "boom") {
+		t.Errorf("Expected the error, got:\n%s", screen)
+	}
+	if len(*runs) != 3 || (*runs)[2] != "input_test" {
+		t.Errorf("Expected three runs ending with input_test, got %v", *runs)
+	}
+
+	d.Handle("j")
+	if screen = render(t, d); !strings.Contains(screen, reverse+"02") || !strings.Contains(screen, "no story for day 2") {
+		t.Errorf("Expected day 2 to be selected, got:\n%s", screen)
+	}
+	d.Handle("j")
+	if d.Selected != 1 {
+		t.Errorf("Expected the selection to stop at the last day, got %d", d.Selected)
+	}
+	d.Handle("1")
+	if d.Selected != 0 || d.Last != nil {
+		t.Errorf("Expected day 1 without a result, got %d %v", d.Selected, d.Last)
+	}
+
+	d.Handle("9")
+	if !strings.Contains(d.Message, "day 9 is not registered") {
+		t.Errorf("Expected a message about day 9, got %q", d.Message)
+	}
+	d.Handle("x")
+	if !strings.Contains(d.Message, "unknown command") {
+		t.Errorf("Expected a message about the command, got %q", d.Message)
+	}
+	if d.Handle("q") {
+		t.Error("Expected q to quit")
+	}
+}
+
+func TestDashboardScrollsStory(t *testing.T) {
+	d, _ := newTestDashboard()
+	d.Handle("n")
+	screen := render(t, d)
+	if strings.Contains(screen, "--- Day 1: Test ---") || !strings.Contains(screen, "line 8") {
+		t.Errorf("Expected the story to scroll, got:\n%s", screen)
+	}
+	d.Handle("n")
+	if d.StoryOffset < 0 || !strings.Contains(render(t, d), "line 8") {
+		t.Errorf("Expected the end of the story to stay visible, offset %d", d.StoryOffset)
+	}
+	d.Handle("b")
+	d.Handle("b")
+	if d.StoryOffset != 0 {
+		t.Errorf("Expected to scroll back to the top, got %d", d.StoryOffset)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		keys     string
+		expected []string
+	}{
+		{"j", []string{"j"}},
+		{"\x1b[A\x1b[B", []string{"k", "j"}},
+		{"\x1bOC\r", []string{"p", "r"}},
+		{"\x1b[6~ \x1b[5~", []string{"n", "n", "b"}},
+		{"7\x1b[1;5A\x01\x03", []string{"7", "q"}},
+		{"\x1b", nil},
+	}
+	for _, tt := range tests {
+		if got := Keys([]byte(tt.keys)); strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("Keys(%q) = %q, expected %q", tt.keys, got, tt.expected)
+		}
+	}
+}
+
+func TestDashboardKeyMode(t *testing.T) {
+	d, _ := newTestDashboard()
+	d.KeyMode = true
+	screen := render(t, d)
+	if !strings.Contains(screen, KeyHelp) || strings.HasSuffix(screen, "> ") {
+		t.Errorf("Expected the keys instead of a prompt, got:\n%s", screen)
+	}
+	d.Handle("?")
+	if d.Message != KeyHelp {
+		t.Errorf("Expected the key help, got %q", d.Message)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		line     string
+		width    int
+		expected []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"one two three four", 9, []string{"one two", "three", "four"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"", 5, []string{""}},
+	}
+	for _, tt := range tests {
+		got := wrap(tt.line, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("wrap(%q, %d) = %q, expected %q", tt.line, tt.width, got, tt.expected)
+		}
+	}
+}
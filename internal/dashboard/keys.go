@@ -0,0 +1,49 @@
+package dashboard
+
+// KeyHelp lists the keys when the dashboard reads single keypresses.
+const KeyHelp = "arrows, j/k: select  p: part  i: input  enter: run  space/b: scroll  q: quit"
+
+// keys maps escape sequences and control keys to commands.
+var keys = map[string]string{
+	"\x1b[A":  "k",
+	"\x1b[B":  "j",
+	"\x1b[C":  "p",
+	"\x1b[D":  "p",
+	"\x1b[5~": "b",
+	"\x1b[6~": "n",
+	"\r":      "r",
+	"\n":      "r",
+	" ":       "n",
+	"\x03":    "q", // Ctrl-C
+	"\x04":    "q", // Ctrl-D
+}
+
+// Keys splits the bytes of one or more keypresses read from a terminal
+// and returns the command of each. Unknown escape sequences and control
+// keys are dropped; other keys are their own command.
+func Keys(b []byte) []string {
+	var cmds []string
+	for len(b) > 0 {
+		n := 1
+		if b[0] == 0x1b && len(b) > 1 && (b[1] == '[' || b[1] == 'O') {
+			// A CSI or SS3 sequence ends with a byte in 0x40-0x7e.
+			n = 2
+			for n < len(b) && (b[n] < 0x40 || b[n] > 0x7e) {
+				n++
+			}
+			n = min(n+1, len(b))
+		}
+		key := string(b[:n])
+		b = b[n:]
+		if len(key) == 3 && key[1] == 'O' {
+			key = "\x1b[" + key[2:] // SS3 arrows, as sent in application mode
+		}
+		switch cmd, ok := keys[key]; {
+		case ok:
+			cmds = append(cmds, cmd)
+		case n == 1 && key[0] >= ' ' && key[0] < 0x7f:
+			cmds = append(cmds, key)
+		}
+	}
+	return cmds
+}
@@ -0,0 +1,248 @@
+// Package geom provides integer 2D and 3D vectors and the plane geometry
+// the puzzles keep needing: distances, axis-aligned rectangles, polygon
+// area, point-in-polygon tests and segment intersection. Everything is
+// exact integer arithmetic; only Dist returns a float.
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// Abs returns the absolute value of n.
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive n.
+func Sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Vec2 is a point or displacement in the plane.
+type Vec2 struct {
+	X, Y int
+}
+
+// Add returns v+w.
+func (v Vec2) Add(w Vec2) Vec2 { return Vec2{v.X + w.X, v.Y + w.Y} }
+
+// Sub returns v-w.
+func (v Vec2) Sub(w Vec2) Vec2 { return Vec2{v.X - w.X, v.Y - w.Y} }
+
+// Scale returns v multiplied by k.
+func (v Vec2) Scale(k int) Vec2 { return Vec2{v.X * k, v.Y * k} }
+
+// Dot returns the dot product of v and w.
+func (v Vec2) Dot(w Vec2) int { return v.X*w.X + v.Y*w.Y }
+
+// Cross returns the z component of the cross product of v and w, which is
+// positive when w turns counter-clockwise from v.
+func (v Vec2) Cross(w Vec2) int { return v.X*w.Y - v.Y*w.X }
+
+// Manhattan returns the taxicab distance between v and w.
+func (v Vec2) Manhattan(w Vec2) int { return Abs(v.X-w.X) + Abs(v.Y-w.Y) }
+
+// DistSq returns the squared Euclidean distance between v and w.
+func (v Vec2) DistSq(w Vec2) int {
+	d := v.Sub(w)
+	return d.Dot(d)
+}
+
+// Dist returns the Euclidean distance between v and w.
+func (v Vec2) Dist(w Vec2) float64 { return math.Sqrt(float64(v.DistSq(w))) }
+
+func (v Vec2) String() string { return fmt.Sprintf("%d,%d", v.X, v.Y) }
+
+// Vec3 is a point or displacement in space.
+type Vec3 struct {
+	X, Y, Z int
+}
+
+// Add returns v+w.
+func (v Vec3) Add(w Vec3) Vec3 { return Vec3{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+
+// Sub returns v-w.
+func (v Vec3) Sub(w Vec3) Vec3 { return Vec3{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+
+// Scale returns v multiplied by k.
+func (v Vec3) Scale(k int) Vec3 { return Vec3{v.X * k, v.Y * k, v.Z * k} }
+
+// Dot returns the dot product of v and w.
+func (v Vec3) Dot(w Vec3) int { return v.X*w.X + v.Y*w.Y + v.Z*w.Z }
+
+// Cross returns the cross product of v and w.
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Manhattan returns the taxicab distance between v and w.
+func (v Vec3) Manhattan(w Vec3) int { return Abs(v.X-w.X) + Abs(v.Y-w.Y) + Abs(v.Z-w.Z) }
+
+// DistSq returns the squared Euclidean distance between v and w.
+func (v Vec3) DistSq(w Vec3) int {
+	d := v.Sub(w)
+	return d.Dot(d)
+}
+
+// Dist returns the Euclidean distance between v and w.
+func (v Vec3) Dist(w Vec3) float64 { return math.Sqrt(float64(v.DistSq(w))) }
+
+func (v Vec3) String() string { return fmt.Sprintf("%d,%d,%d", v.X, v.Y, v.Z) }
+
+// Rect is an axis-aligned rectangle of grid cells. Both corners are
+// included, so a rectangle with Min == Max is a single cell.
+type Rect struct {
+	Min, Max Vec2
+}
+
+// RectFrom returns the rectangle with opposite corners a and b.
+func RectFrom(a, b Vec2) Rect {
+	return Rect{
+		Min: Vec2{min(a.X, b.X), min(a.Y, b.Y)},
+		Max: Vec2{max(a.X, b.X), max(a.Y, b.Y)},
+	}
+}
+
+// Width returns the number of columns the rectangle covers.
+func (r Rect) Width() int { return r.Max.X - r.Min.X + 1 }
+
+// Height returns the number of rows the rectangle covers.
+func (r Rect) Height() int { return r.Max.Y - r.Min.Y + 1 }
+
+// Area returns the number of cells the rectangle covers.
+func (r Rect) Area() int { return r.Width() * r.Height() }
+
+// Contains reports whether p lies inside r or on its border.
+func (r Rect) Contains(p Vec2) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Overlaps reports whether r and o share a cell.
+func (r Rect) Overlaps(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X && r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+// Corners returns the corners clockwise from Min, with y growing downwards.
+func (r Rect) Corners() [4]Vec2 {
+	return [4]Vec2{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}
+}
+
+// Segment is the line segment between A and B, both included.
+type Segment struct {
+	A, B Vec2
+}
+
+// orientation returns the sign of the turn a→b→c: 1 counter-clockwise,
+// -1 clockwise and 0 collinear.
+func orientation(a, b, c Vec2) int {
+	return Sign(b.Sub(a).Cross(c.Sub(a)))
+}
+
+// Contains reports whether p lies on the segment.
+func (s Segment) Contains(p Vec2) bool {
+	return orientation(s.A, s.B, p) == 0 && RectFrom(s.A, s.B).Contains(p)
+}
+
+// Intersects reports whether s and t share at least one point, including
+// touching endpoints and collinear overlaps.
+func (s Segment) Intersects(t Segment) bool {
+	o1 := orientation(s.A, s.B, t.A)
+	o2 := orientation(s.A, s.B, t.B)
+	o3 := orientation(t.A, t.B, s.A)
+	o4 := orientation(t.A, t.B, s.B)
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true // proper crossing
+	}
+	// Otherwise they can only meet at an endpoint lying on the other segment.
+	return s.Contains(t.A) || s.Contains(t.B) || t.Contains(s.A) || t.Contains(s.B)
+}
+
+// Polygon is a closed polygon given by its vertices in order; the last
+// vertex connects back to the first.
+type Polygon []Vec2
+
+// Edge returns the i-th edge, from vertex i to the next one.
+func (p Polygon) Edge(i int) Segment {
+	return Segment{p[i], p[(i+1)%len(p)]}
+}
+
+// DoubleArea returns twice the signed area by the shoelace formula. It is
+// positive for counter-clockwise vertices (with y growing upwards) and
+// always an integer.
+func (p Polygon) DoubleArea() int {
+	sum := 0
+	for i := range p {
+		e := p.Edge(i)
+		sum += e.A.Cross(e.B)
+	}
+	return sum
+}
+
+// Area returns the unsigned area enclosed by the polygon.
+func (p Polygon) Area() float64 {
+	return float64(Abs(p.DoubleArea())) / 2
+}
+
+// Location is where a point lies relative to a polygon.
+type Location int
+
+const (
+	Outside Location = iota
+	Boundary
+	Inside
+)
+
+func (l Location) String() string {
+	switch l {
+	case Outside:
+		return "outside"
+	case Boundary:
+		return "boundary"
+	case Inside:
+		return "inside"
+	}
+	return fmt.Sprintf("Location(%d)", int(l))
+}
+
+// Locate tells whether q lies inside the polygon, on one of its edges or
+// outside. Points strictly inside are found by casting a ray towards +x
+// and counting the edges it crosses, with each edge taken as half-open in
+// y so that vertices on the ray are counted once.
+func (p Polygon) Locate(q Vec2) Location {
+	inside := false
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		crosses := (a.Y > q.Y) != (b.Y > q.Y)
+		if !crosses && a.Y != q.Y && b.Y != q.Y {
+			continue // the edge is wholly above or below q
+		}
+		o := orientation(a, b, q)
+		if o == 0 && min(a.X, b.X) <= q.X && q.X <= max(a.X, b.X) && min(a.Y, b.Y) <= q.Y && q.Y <= max(a.Y, b.Y) {
+			return Boundary
+		}
+		// The edge crosses the ray's line; q is left of the crossing when
+		// the turn a→b→q agrees with the edge's vertical direction.
+		if crosses && o == Sign(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return Inside
+	}
+	return Outside
+}
+
+// Contains reports whether q lies inside the polygon or on its boundary.
+func (p Polygon) Contains(q Vec2) bool {
+	return p.Locate(q) != Outside
+}
@@ -0,0 +1,371 @@
+package geom
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestVec2(t *testing.T) {
+	v, w := Vec2{3, -4}, Vec2{-1, 2}
+	tests := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{"add", v.Add(w), Vec2{2, -2}},
+		{"sub", v.Sub(w), Vec2{4, -6}},
+		{"scale", v.Scale(-2), Vec2{-6, 8}},
+		{"dot", v.Dot(w), -11},
+		{"cross", v.Cross(w), 2},
+		{"cross reversed", w.Cross(v), -2},
+		{"manhattan", v.Manhattan(w), 10},
+		{"distsq", v.DistSq(w), 52},
+		{"dist to origin", v.Dist(Vec2{}), 5.0},
+		{"string", v.String(), "3,-4"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestVec3(t *testing.T) {
+	v, w := Vec3{1, 2, 3}, Vec3{4, -5, 6}
+	tests := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{"add", v.Add(w), Vec3{5, -3, 9}},
+		{"sub", v.Sub(w), Vec3{-3, 7, -3}},
+		{"scale", v.Scale(3), Vec3{3, 6, 9}},
+		{"dot", v.Dot(w), 12},
+		{"cross", v.Cross(w), Vec3{27, 6, -13}},
+		{"manhattan", v.Manhattan(w), 13},
+		{"distsq", v.DistSq(w), 67},
+		{"dist", v.Dist(w), math.Sqrt(67)},
+		{"string", v.String(), "1,2,3"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+
+	// The cross product is perpendicular to both factors.
+	for _, a := range []Vec3{{1, 0, 0}, {2, 3, -1}, {-4, 5, 7}} {
+		for _, b := range []Vec3{{0, 1, 0}, {1, 1, 1}, {3, -2, 8}} {
+			c := a.Cross(b)
+			if c.Dot(a) != 0 || c.Dot(b) != 0 {
+				t.Errorf("%v x %v = %v is not perpendicular", a, b, c)
+			}
+		}
+	}
+}
+
+func TestDistancesAgree(t *testing.T) {
+	for x := -3; x <= 3; x++ {
+		for y := -3; y <= 3; y++ {
+			for z := -3; z <= 3; z++ {
+				v := Vec3{x, y, z}
+				if v.DistSq(Vec3{}) != x*x+y*y+z*z || v.Manhattan(Vec3{}) != Abs(x)+Abs(y)+Abs(z) {
+					t.Fatalf("Wrong distances for %v", v)
+				}
+				if d := v.Dist(Vec3{}); d*d-float64(v.DistSq(Vec3{})) > 1e-9 {
+					t.Fatalf("Dist(%v) = %v disagrees with DistSq", v, d)
+				}
+				if (Vec2{x, y}).DistSq(Vec2{z, 0}) != (Vec2{z, 0}).DistSq(Vec2{x, y}) {
+					t.Fatalf("DistSq is not symmetric for %v", v)
+				}
+			}
+		}
+	}
+}
+
+func TestAbsSign(t *testing.T) {
+	tests := []struct{ n, abs, sign int }{{-7, 7, -1}, {0, 0, 0}, {5, 5, 1}}
+	for _, tt := range tests {
+		if Abs(tt.n) != tt.abs || Sign(tt.n) != tt.sign {
+			t.Errorf("Abs/Sign(%d): expected %d %d, got %d %d", tt.n, tt.abs, tt.sign, Abs(tt.n), Sign(tt.n))
+		}
+	}
+}
+
+func TestRect(t *testing.T) {
+	r := RectFrom(Vec2{7, 1}, Vec2{2, 5})
+	if r != (Rect{Vec2{2, 1}, Vec2{7, 5}}) {
+		t.Fatalf("Expected normalized corners, got %v", r)
+	}
+	if r.Width() != 6 || r.Height() != 5 || r.Area() != 30 {
+		t.Errorf("Expected 6x5=30, got %dx%d=%d", r.Width(), r.Height(), r.Area())
+	}
+	if RectFrom(Vec2{4, 4}, Vec2{4, 4}).Area() != 1 {
+		t.Error("Expected a single cell to have area 1")
+	}
+	expected := [4]Vec2{{2, 1}, {7, 1}, {7, 5}, {2, 5}}
+	if r.Corners() != expected {
+		t.Errorf("Expected corners %v, got %v", expected, r.Corners())
+	}
+}
+
+// rects returns every rectangle with corners in [0,n)².
+func rects(n int) []Rect {
+	var all []Rect
+	for x1 := 0; x1 < n; x1++ {
+		for y1 := 0; y1 < n; y1++ {
+			for x2 := x1; x2 < n; x2++ {
+				for y2 := y1; y2 < n; y2++ {
+					all = append(all, Rect{Vec2{x1, y1}, Vec2{x2, y2}})
+				}
+			}
+		}
+	}
+	return all
+}
+
+func TestRectAgainstCells(t *testing.T) {
+	const n = 4
+	all := rects(n)
+	cells := func(r Rect) map[Vec2]bool {
+		m := make(map[Vec2]bool)
+		for x := -1; x <= n; x++ {
+			for y := -1; y <= n; y++ {
+				if r.Contains(Vec2{x, y}) {
+					m[Vec2{x, y}] = true
+				}
+			}
+		}
+		return m
+	}
+
+	for _, r := range all {
+		rc := cells(r)
+		if len(rc) != r.Area() {
+			t.Fatalf("%v: Area %d but contains %d cells", r, r.Area(), len(rc))
+		}
+		// A rectangle's polygon holds the same points, with the border
+		// on its boundary.
+		corners := r.Corners()
+		poly := Polygon(corners[:])
+		for x := -1; x <= n; x++ {
+			for y := -1; y <= n; y++ {
+				p := Vec2{x, y}
+				if poly.Contains(p) != rc[p] {
+					t.Fatalf("%v: polygon and rectangle disagree at %v", r, p)
+				}
+			}
+		}
+
+		for _, o := range all {
+			shared := false
+			for p := range cells(o) {
+				if rc[p] {
+					shared = true
+					break
+				}
+			}
+			if r.Overlaps(o) != shared {
+				t.Fatalf("Overlaps(%v, %v) = %v, expected %v", r, o, r.Overlaps(o), shared)
+			}
+		}
+	}
+}
+
+// ratIntersects decides segment intersection by solving for the crossing
+// with exact fractions, independently of Segment.Intersects.
+func ratIntersects(s, t Segment) bool {
+	if s.A == s.B && t.A == t.B {
+		return s.A == t.A
+	}
+	if s.A == s.B {
+		return onSegment(t, s.A)
+	}
+	if t.A == t.B {
+		return onSegment(s, t.A)
+	}
+
+	r, q := s.B.Sub(s.A), t.B.Sub(t.A)
+	denom := r.Cross(q)
+	d := t.A.Sub(s.A)
+	if denom == 0 {
+		if d.Cross(r) != 0 {
+			return false // parallel lines
+		}
+		// Collinear: project t onto s and check the overlap of [0, 1].
+		rr := r.Dot(r)
+		t0, t1 := d.Dot(r), t.B.Sub(s.A).Dot(r)
+		return max(min(t0, t1), 0) <= min(max(t0, t1), rr)
+	}
+
+	u := big.NewRat(int64(d.Cross(q)), int64(denom))
+	v := big.NewRat(int64(d.Cross(r)), int64(denom))
+	zero, one := new(big.Rat), big.NewRat(1, 1)
+	return u.Cmp(zero) >= 0 && u.Cmp(one) <= 0 && v.Cmp(zero) >= 0 && v.Cmp(one) <= 0
+}
+
+// onSegment checks p against the segment's parametric form.
+func onSegment(s Segment, p Vec2) bool {
+	if s.A == s.B {
+		return p == s.A
+	}
+	r, d := s.B.Sub(s.A), p.Sub(s.A)
+	return r.Cross(d) == 0 && d.Dot(r) >= 0 && d.Dot(r) <= r.Dot(r)
+}
+
+func TestSegmentIntersectsExhaustive(t *testing.T) {
+	var points []Vec2
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			points = append(points, Vec2{x, y})
+		}
+	}
+	var segments []Segment
+	for _, a := range points {
+		for _, b := range points {
+			segments = append(segments, Segment{a, b})
+		}
+	}
+
+	for _, s := range segments {
+		for _, p := range points {
+			if s.Contains(p) != onSegment(s, p) {
+				t.Fatalf("%v.Contains(%v) = %v", s, p, s.Contains(p))
+			}
+		}
+		for _, o := range segments {
+			expected := ratIntersects(s, o)
+			if s.Intersects(o) != expected {
+				t.Fatalf("%v.Intersects(%v) = %v, expected %v", s, o, s.Intersects(o), expected)
+			}
+		}
+	}
+}
+
+func TestSegmentIntersects(t *testing.T) {
+	tests := []struct {
+		name     string
+		s, o     Segment
+		expected bool
+	}{
+		{"crossing", Segment{Vec2{0, 0}, Vec2{4, 4}}, Segment{Vec2{0, 4}, Vec2{4, 0}}, true},
+		{"touching end", Segment{Vec2{0, 0}, Vec2{2, 2}}, Segment{Vec2{2, 2}, Vec2{5, 0}}, true},
+		{"T junction", Segment{Vec2{0, 0}, Vec2{4, 0}}, Segment{Vec2{2, 0}, Vec2{2, 5}}, true},
+		{"collinear overlap", Segment{Vec2{0, 0}, Vec2{4, 0}}, Segment{Vec2{3, 0}, Vec2{9, 0}}, true},
+		{"collinear apart", Segment{Vec2{0, 0}, Vec2{2, 0}}, Segment{Vec2{3, 0}, Vec2{9, 0}}, false},
+		{"parallel", Segment{Vec2{0, 0}, Vec2{4, 0}}, Segment{Vec2{0, 1}, Vec2{4, 1}}, false},
+		{"line crosses but segment stops", Segment{Vec2{0, 0}, Vec2{1, 1}}, Segment{Vec2{0, 4}, Vec2{4, 0}}, false},
+		{"far coordinates", Segment{Vec2{-100000, 0}, Vec2{100000, 1}}, Segment{Vec2{0, -100000}, Vec2{1, 100000}}, true},
+	}
+	for _, tt := range tests {
+		if tt.s.Intersects(tt.o) != tt.expected || tt.o.Intersects(tt.s) != tt.expected {
+			t.Errorf("%s: expected %v", tt.name, tt.expected)
+		}
+	}
+}
+
+// polygons are simple polygons, clockwise and counter-clockwise, convex
+// and not, with axis-aligned and slanted edges.
+var polygons = map[string]Polygon{
+	"square":   {{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+	"triangle": {{0, 0}, {6, 0}, {0, 6}},
+	"L":        {{0, 0}, {2, 0}, {2, 3}, {5, 3}, {5, 5}, {0, 5}},
+	"U":        {{0, 0}, {1, 0}, {1, 4}, {3, 4}, {3, 0}, {4, 0}, {4, 5}, {0, 5}},
+	"arrow":    {{0, 0}, {6, 3}, {0, 6}, {2, 3}},
+	"diamond":  {{3, 0}, {6, 3}, {3, 6}, {0, 3}},
+	"comb":     {{0, 0}, {1, 2}, {2, 0}, {3, 2}, {4, 0}, {4, 4}, {0, 4}},
+	"day09":    {{7, 1}, {11, 1}, {11, 7}, {9, 7}, {9, 5}, {2, 5}, {2, 3}, {7, 3}},
+}
+
+func gcd(a, b int) int {
+	a, b = Abs(a), Abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// TestPolygonPick checks Locate and DoubleArea against each other with
+// Pick's theorem, A = I + B/2 - 1, counting every lattice point in the
+// bounding box. B is the number of lattice points on the edges.
+func TestPolygonPick(t *testing.T) {
+	for name, poly := range polygons {
+		for _, p := range []Polygon{poly, reversed(poly)} {
+			boundary := 0
+			for i := range p {
+				e := p.Edge(i)
+				boundary += gcd(e.B.X-e.A.X, e.B.Y-e.A.Y)
+			}
+
+			counts := map[Location]int{}
+			for x := -2; x <= 13; x++ {
+				for y := -2; y <= 13; y++ {
+					counts[p.Locate(Vec2{x, y})]++
+				}
+			}
+			if counts[Boundary] != boundary {
+				t.Errorf("%s: expected %d boundary points, got %d", name, boundary, counts[Boundary])
+			}
+			// 2A = 2I + B - 2
+			if 2*counts[Inside]+boundary-2 != Abs(p.DoubleArea()) {
+				t.Errorf("%s: Pick's theorem fails with I=%d B=%d 2A=%d", name, counts[Inside], boundary, p.DoubleArea())
+			}
+		}
+	}
+}
+
+func reversed(p Polygon) Polygon {
+	r := make(Polygon, len(p))
+	for i, v := range p {
+		r[len(p)-1-i] = v
+	}
+	return r
+}
+
+func TestPolygonArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{"square", 16}, {"triangle", 18}, {"L", 16}, {"U", 12}, {"arrow", 12}, {"diamond", 18}, {"comb", 12}, {"day09", 30},
+	}
+	for _, tt := range tests {
+		p := polygons[tt.name]
+		if p.Area() != tt.expected || reversed(p).Area() != tt.expected {
+			t.Errorf("%s: expected area %v, got %v", tt.name, tt.expected, p.Area())
+		}
+		if Sign(p.DoubleArea()) != -Sign(reversed(p).DoubleArea()) {
+			t.Errorf("%s: expected reversing to flip the sign of the area", tt.name)
+		}
+	}
+}
+
+func TestPolygonLocate(t *testing.T) {
+	u := polygons["U"]
+	tests := []struct {
+		p        Vec2
+		expected Location
+	}{
+		{Vec2{2, 2}, Outside},  // in the gap of the U
+		{Vec2{2, 4}, Boundary}, // bottom of the gap
+		{Vec2{0, 0}, Boundary}, // vertex
+		{Vec2{2, 5}, Boundary}, // on the long edge
+		{Vec2{2, 6}, Outside},
+		{Vec2{3, 2}, Boundary},
+		{Vec2{-1, 4}, Outside}, // ray passes through vertices
+		{Vec2{1, 4}, Boundary},
+		{Vec2{2, 0}, Outside}, // ray runs along the bottom edges
+	}
+	for _, tt := range tests {
+		if got := u.Locate(tt.p); got != tt.expected {
+			t.Errorf("Locate(%v) = %v, expected %v", tt.p, got, tt.expected)
+		}
+	}
+	if (Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}).Locate(Vec2{2, 2}) != Inside {
+		t.Error("Expected the centre of a square to be inside")
+	}
+	if Location(7).String() != "Location(7)" || Inside.String() != "inside" {
+		t.Error("Unexpected Location names")
+	}
+}
@@ -0,0 +1,315 @@
+// Package grid provides a rectangular 2D map of cells addressed by row and
+// column, as found in most puzzle inputs. Cells outside the grid are never
+// an error to look at: Get reports them as absent and the neighbour
+// iterators skip them, so callers need no bounds checks of their own.
+package grid
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/abtris/aoc2025/internal/input"
+)
+
+// Point is a cell position, or a direction when added to one.
+type Point struct {
+	Row, Col int
+}
+
+// Add returns p moved by d.
+func (p Point) Add(d Point) Point {
+	return Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.Row, p.Col)
+}
+
+// Directions, with rows growing downwards.
+var (
+	Up    = Point{Row: -1}
+	Down  = Point{Row: 1}
+	Left  = Point{Col: -1}
+	Right = Point{Col: 1}
+
+	// Dirs4 are the orthogonal neighbours, clockwise from Up.
+	Dirs4 = []Point{Up, Right, Down, Left}
+	// Dirs8 adds the diagonals, clockwise from Up.
+	Dirs8 = []Point{Up, {-1, 1}, Right, {1, 1}, Down, {1, -1}, Left, {-1, -1}}
+)
+
+// Grid is a rectangular map of cells stored row by row.
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// New returns a grid of zero cells.
+func New[T any](rows, cols int) *Grid[T] {
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// From copies rows into a grid. All rows must have the same length.
+func From[T any](rows [][]T) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return New[T](0, 0), nil
+	}
+	g := New[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != g.cols {
+			return nil, fmt.Errorf("row %d has width %d, expected %d", i, len(row), g.cols)
+		}
+		copy(g.cells[i*g.cols:], row)
+	}
+	return g, nil
+}
+
+// Parse reads a character map like input.Grid does.
+func Parse(r io.Reader, allowed string) (*Grid[byte], error) {
+	rows, err := input.Grid(r, allowed)
+	if err != nil {
+		return nil, err
+	}
+	return From(rows)
+}
+
+// Rows returns the number of rows.
+func (g *Grid[T]) Rows() int { return g.rows }
+
+// Cols returns the number of columns.
+func (g *Grid[T]) Cols() int { return g.cols }
+
+// In reports whether p lies inside the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.Row >= 0 && p.Row < g.rows && p.Col >= 0 && p.Col < g.cols
+}
+
+// At returns the cell at p, which must lie inside the grid.
+func (g *Grid[T]) At(p Point) T {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %v outside %dx%d grid", p, g.rows, g.cols))
+	}
+	return g.cells[p.Row*g.cols+p.Col]
+}
+
+// Get returns the cell at p and whether p lies inside the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set changes the cell at p, which must lie inside the grid.
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %v outside %dx%d grid", p, g.rows, g.cols))
+	}
+	g.cells[p.Row*g.cols+p.Col] = v
+}
+
+// All yields every cell row by row.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{Row: i / g.cols, Col: i % g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors yields the cells at p moved by each of dirs that lie inside
+// the grid.
+func (g *Grid[T]) Neighbors(p Point, dirs []Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, d := range dirs {
+			q := p.Add(d)
+			if v, ok := g.Get(q); ok && !yield(q, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 yields the orthogonal neighbours of p inside the grid.
+func (g *Grid[T]) Neighbors4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, Dirs4)
+}
+
+// Neighbors8 yields the orthogonal and diagonal neighbours of p inside
+// the grid.
+func (g *Grid[T]) Neighbors8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, Dirs8)
+}
+
+// FindFunc returns the first cell, row by row, for which match is true.
+func (g *Grid[T]) FindFunc(match func(T) bool) (Point, bool) {
+	for p, v := range g.All() {
+		if match(v) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAllFunc returns every cell, row by row, for which match is true.
+func (g *Grid[T]) FindAllFunc(match func(T) bool) []Point {
+	var found []Point
+	for p, v := range g.All() {
+		if match(v) {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// Char is a cell type holding a character.
+type Char interface {
+	~byte | ~rune
+}
+
+// Find returns the first cell, row by row, holding c.
+func Find[T Char](g *Grid[T], c rune) (Point, bool) {
+	return g.FindFunc(func(v T) bool { return rune(v) == c })
+}
+
+// FindAll returns every cell, row by row, holding c.
+func FindAll[T Char](g *Grid[T], c rune) []Point {
+	return g.FindAllFunc(func(v T) bool { return rune(v) == c })
+}
+
+// View is a row or column of a grid. It shares the grid's cells, so Set
+// changes the grid.
+type View[T any] struct {
+	g     *Grid[T]
+	start Point
+	step  Point
+	n     int
+}
+
+// Row returns a view of row i.
+func (g *Grid[T]) Row(i int) View[T] {
+	if i < 0 || i >= g.rows {
+		panic(fmt.Sprintf("grid: row %d outside %dx%d grid", i, g.rows, g.cols))
+	}
+	return View[T]{g: g, start: Point{Row: i}, step: Right, n: g.cols}
+}
+
+// Col returns a view of column j.
+func (g *Grid[T]) Col(j int) View[T] {
+	if j < 0 || j >= g.cols {
+		panic(fmt.Sprintf("grid: column %d outside %dx%d grid", j, g.rows, g.cols))
+	}
+	return View[T]{g: g, start: Point{Col: j}, step: Down, n: g.rows}
+}
+
+// Len returns the number of cells in the view.
+func (v View[T]) Len() int { return v.n }
+
+// point returns the grid position of the view's i-th cell.
+func (v View[T]) point(i int) Point {
+	if i < 0 || i >= v.n {
+		panic(fmt.Sprintf("grid: index %d outside view of length %d", i, v.n))
+	}
+	return Point{Row: v.start.Row + i*v.step.Row, Col: v.start.Col + i*v.step.Col}
+}
+
+// At returns the view's i-th cell.
+func (v View[T]) At(i int) T { return v.g.At(v.point(i)) }
+
+// Set changes the view's i-th cell.
+func (v View[T]) Set(i int, x T) { v.g.Set(v.point(i), x) }
+
+// Values returns a copy of the view's cells.
+func (v View[T]) Values() []T {
+	values := make([]T, v.n)
+	for i := range values {
+		values[i] = v.At(i)
+	}
+	return values
+}
+
+// All yields the view's cells with their grid positions.
+func (v View[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i := 0; i < v.n; i++ {
+			p := v.point(i)
+			if !yield(p, v.g.At(p)) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := New[T](g.rows, g.cols)
+	copy(c.cells, g.cells)
+	return c
+}
+
+// Transpose returns a new grid with rows and columns swapped.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := New[T](g.cols, g.rows)
+	for p, v := range g.All() {
+		t.Set(Point{Row: p.Col, Col: p.Row}, v)
+	}
+	return t
+}
+
+// RotateCW returns a new grid turned a quarter clockwise.
+func (g *Grid[T]) RotateCW() *Grid[T] {
+	t := New[T](g.cols, g.rows)
+	for p, v := range g.All() {
+		t.Set(Point{Row: p.Col, Col: g.rows - 1 - p.Row}, v)
+	}
+	return t
+}
+
+// RotateCCW returns a new grid turned a quarter counter-clockwise.
+func (g *Grid[T]) RotateCCW() *Grid[T] {
+	t := New[T](g.cols, g.rows)
+	for p, v := range g.All() {
+		t.Set(Point{Row: g.cols - 1 - p.Col, Col: p.Row}, v)
+	}
+	return t
+}
+
+// String prints the grid one row per line. Byte and rune cells are
+// printed as characters side by side; other cells are right-aligned in
+// space separated columns.
+func (g *Grid[T]) String() string {
+	cells := make([]string, len(g.cells))
+	width := 0
+	for i, v := range g.cells {
+		switch c := any(v).(type) {
+		case byte:
+			cells[i] = string(rune(c))
+		case rune:
+			cells[i] = string(c)
+		default:
+			cells[i] = fmt.Sprint(v)
+			width = max(width, len(cells[i]))
+		}
+	}
+
+	var b strings.Builder
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			cell := cells[row*g.cols+col]
+			if width > 0 {
+				if col > 0 {
+					b.WriteByte(' ')
+				}
+				cell = fmt.Sprintf("%*s", width, cell)
+			}
+			b.WriteString(cell)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
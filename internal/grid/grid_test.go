@@ -0,0 +1,219 @@
+package grid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/input"
+)
+
+const sample = `
+abc
+def
+`
+
+func parse(t *testing.T, s string) *Grid[byte] {
+	t.Helper()
+	g, err := Parse(strings.NewReader(s), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := parse(t, sample)
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("Expected 2x3 grid, got %dx%d", g.Rows(), g.Cols())
+	}
+	if g.String() != "abc\ndef\n" {
+		t.Errorf("Expected %q, got %q", "abc\ndef\n", g.String())
+	}
+
+	if _, err := Parse(strings.NewReader("ab\nc\n"), ""); err == nil {
+		t.Error("Expected error for ragged rows")
+	}
+
+	src := &input.Source{R: strings.NewReader("ab\nc\nde\n"), Lenient: true}
+	g, err := Parse(src, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != "ab\nde\n" || len(src.Warnings) != 1 {
+		t.Errorf("Expected ragged row skipped with a warning, got %q and %v", g.String(), src.Warnings)
+	}
+
+	src = &input.Source{R: strings.NewReader("@x@\n@@\n..\n"), Lenient: true}
+	if g, err = Parse(src, ".@"); err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != "@@\n..\n" || len(src.Warnings) != 1 {
+		t.Errorf("Expected bad first row skipped with a warning, got %q and %v", g.String(), src.Warnings)
+	}
+
+	if g := parse(t, "\n"); g.Rows() != 0 || g.String() != "" {
+		t.Errorf("Expected empty grid, got %q", g.String())
+	}
+}
+
+func TestFrom(t *testing.T) {
+	g, err := From([][]int{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.At(Point{1, 0}) != 3 {
+		t.Errorf("Expected 3, got %d", g.At(Point{1, 0}))
+	}
+	if _, err := From([][]int{{1, 2}, {3}}); err == nil || err.Error() != "row 1 has width 1, expected 2" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	g := parse(t, sample)
+	tests := []struct {
+		p        Point
+		expected byte
+		ok       bool
+	}{
+		{Point{0, 0}, 'a', true},
+		{Point{1, 2}, 'f', true},
+		{Point{-1, 0}, 0, false},
+		{Point{0, 3}, 0, false},
+		{Point{2, 0}, 0, false},
+	}
+	for _, tt := range tests {
+		v, ok := g.Get(tt.p)
+		if v != tt.expected || ok != tt.ok {
+			t.Errorf("Get(%v): expected %q %v, got %q %v", tt.p, tt.expected, tt.ok, v, ok)
+		}
+		if g.In(tt.p) != tt.ok {
+			t.Errorf("In(%v): expected %v", tt.p, tt.ok)
+		}
+	}
+
+	g.Set(Point{1, 1}, 'X')
+	if g.String() != "abc\ndXf\n" {
+		t.Errorf("Unexpected grid after Set: %q", g.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected At outside the grid to panic")
+		}
+	}()
+	g.At(Point{5, 5})
+}
+
+func TestNeighbors(t *testing.T) {
+	g := parse(t, "abc\ndef\nghi\n")
+	collect := func(seq func(func(Point, byte) bool)) string {
+		var s []byte
+		for _, v := range seq {
+			s = append(s, v)
+		}
+		return string(s)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"4 of centre", collect(g.Neighbors4(Point{1, 1})), "bfhd"},
+		{"8 of centre", collect(g.Neighbors8(Point{1, 1})), "bcfihgda"},
+		{"4 of corner", collect(g.Neighbors4(Point{0, 0})), "bd"},
+		{"8 of corner", collect(g.Neighbors8(Point{2, 2})), "fhe"},
+		{"8 of edge", collect(g.Neighbors8(Point{0, 1})), "cfeda"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, tt.got)
+		}
+	}
+
+	// Stopping early must not call yield again.
+	for range g.Neighbors8(Point{1, 1}) {
+		break
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := parse(t, ".S.\n^.^\n")
+	if p, ok := Find(g, 'S'); !ok || p != (Point{0, 1}) {
+		t.Errorf("Expected S at (0,1), got %v %v", p, ok)
+	}
+	if _, ok := Find(g, 'X'); ok {
+		t.Error("Expected X not to be found")
+	}
+	if found := FindAll(g, '^'); !reflect.DeepEqual(found, []Point{{1, 0}, {1, 2}}) {
+		t.Errorf("Unexpected splitters %v", found)
+	}
+
+	runes, _ := From([][]rune{[]rune("äö"), []rune("üß")})
+	if p, ok := Find(runes, 'ß'); !ok || p != (Point{1, 1}) {
+		t.Errorf("Expected ß at (1,1), got %v %v", p, ok)
+	}
+	if runes.String() != "äö\nüß\n" {
+		t.Errorf("Unexpected rune grid %q", runes.String())
+	}
+}
+
+func TestViews(t *testing.T) {
+	g := parse(t, sample)
+	if got := string(g.Row(1).Values()); got != "def" {
+		t.Errorf("Expected row def, got %q", got)
+	}
+	col := g.Col(2)
+	if col.Len() != 2 || string(col.Values()) != "cf" {
+		t.Errorf("Expected column cf, got %q", col.Values())
+	}
+
+	col.Set(0, 'Z')
+	if g.At(Point{0, 2}) != 'Z' {
+		t.Error("Expected Set on a view to change the grid")
+	}
+
+	var points []Point
+	for p := range g.Row(0).All() {
+		points = append(points, p)
+	}
+	if !reflect.DeepEqual(points, []Point{{0, 0}, {0, 1}, {0, 2}}) {
+		t.Errorf("Unexpected row points %v", points)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	g := parse(t, sample)
+	tests := []struct {
+		name     string
+		got      *Grid[byte]
+		expected string
+	}{
+		{"transpose", g.Transpose(), "ad\nbe\ncf\n"},
+		{"clockwise", g.RotateCW(), "da\neb\nfc\n"},
+		{"counter-clockwise", g.RotateCCW(), "cf\nbe\nad\n"},
+		{"four turns", g.RotateCW().RotateCW().RotateCW().RotateCW(), "abc\ndef\n"},
+		{"there and back", g.RotateCW().RotateCCW(), "abc\ndef\n"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, tt.got.String())
+		}
+	}
+
+	c := g.Clone()
+	c.Set(Point{0, 0}, 'X')
+	if g.At(Point{0, 0}) != 'a' {
+		t.Error("Expected the clone not to share cells")
+	}
+}
+
+func TestString(t *testing.T) {
+	g, _ := From([][]int{{1, 20}, {300, 4}})
+	expected := "  1  20\n300   4\n"
+	if g.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, g.String())
+	}
+}
@@ -0,0 +1,433 @@
+// Package input reads the puzzle input formats shared by several days.
+//
+// Readers are strict by default: malformed content is reported as a
+// *ParseError carrying the input name and the 1-based line and column of the
+// problem. Wrapping the input in a lenient Source makes the readers skip
+// malformed items instead and record them as warnings.
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineSize is large enough for single-line inputs such as day 2.
+const maxLineSize = 1024 * 1024
+
+// ParseError describes malformed input at a given position.
+type ParseError struct {
+	Name string // input name, usually the file name
+	Line int    // 1-based line number
+	Col  int    // 1-based byte column, 0 if the whole line is wrong
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	pos := fmt.Sprintf("%d", e.Line)
+	if e.Col != 0 {
+		pos = fmt.Sprintf("%d:%d", e.Line, e.Col)
+	}
+	if e.Name != "" {
+		pos = e.Name + ":" + pos
+	}
+	return pos + ": " + e.Msg
+}
+
+// Source is an io.Reader that carries the input name used in errors and
+// the parse mode.
+type Source struct {
+	R       io.Reader
+	Name    string
+	Lenient bool
+
+	// Warnings collects the problems skipped in lenient mode.
+	Warnings []error
+}
+
+func (s *Source) Read(p []byte) (int, error) {
+	return s.R.Read(p)
+}
+
+// sourceOf returns r as a Source. Plain readers are strict and named after
+// the file when r is an *os.File.
+func sourceOf(r io.Reader) *Source {
+	if s, ok := r.(*Source); ok {
+		return s
+	}
+	s := &Source{R: r}
+	if named, ok := r.(interface{ Name() string }); ok {
+		s.Name = named.Name()
+	}
+	return s
+}
+
+// Range is an inclusive integer range written as "start-end".
+type Range struct {
+	Start, End int
+}
+
+// Section is a block of consecutive lines.
+type Section struct {
+	Start int // line number of Lines[0]
+	Lines []string
+
+	src *Source
+}
+
+// Lines returns every line of r without line terminators.
+func Lines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Read returns all of r as a single section.
+func Read(r io.Reader) (Section, error) {
+	src := sourceOf(r)
+	lines, err := Lines(src)
+	return Section{Start: 1, Lines: lines, src: src}, err
+}
+
+// Each calls fn with every line of r, as a one-line section, as soon as it
+// is read, so r may be a stream that never ends. It stops at the first
+// error from fn or from reading.
+func Each(r io.Reader, fn func(Section) error) error {
+	src := sourceOf(r)
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if err := fn(Section{Start: n, Lines: []string{line}, src: src}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Sections splits r into blocks separated by one or more blank lines.
+func Sections(r io.Reader) ([]Section, error) {
+	src := sourceOf(r)
+	lines, err := Lines(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []Section
+	var current *Section
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sections = append(sections, Section{Start: i + 1, src: src})
+			current = &sections[len(sections)-1]
+		}
+		current.Lines = append(current.Lines, line)
+	}
+
+	return sections, nil
+}
+
+// Grid reads a rectangular character map, ignoring blank lines. allowed
+// lists the valid cell bytes; an empty string allows any byte.
+func Grid(r io.Reader, allowed string) ([][]byte, error) {
+	s, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.Grid(allowed)
+}
+
+// IntCSV reads one comma separated list of integers per non-blank line.
+func IntCSV(r io.Reader) ([][]int, error) {
+	s, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.IntCSV()
+}
+
+// Ranges reads "a-b" ranges separated by commas and/or newlines.
+func Ranges(r io.Reader) ([]Range, error) {
+	s, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.Ranges()
+}
+
+// Points2D reads one "x,y" point per non-blank line.
+func Points2D(r io.Reader) ([][2]int, error) {
+	s, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.Points2D()
+}
+
+// Points3D reads one "x,y,z" point per non-blank line.
+func Points3D(r io.Reader) ([][3]int, error) {
+	s, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.Points3D()
+}
+
+// Errorf reports malformed content at column col of s.Lines[i]. In strict
+// mode it returns a *ParseError. In lenient mode the error is recorded as a
+// warning on the source and Errorf returns nil, so the caller should skip
+// the offending item and carry on.
+func (s Section) Errorf(i, col int, format string, args ...any) error {
+	err := &ParseError{Line: s.Start + i, Col: col, Msg: fmt.Sprintf(format, args...)}
+	if s.src == nil {
+		return err
+	}
+	err.Name = s.src.Name
+	if s.src.Lenient {
+		s.src.Warnings = append(s.src.Warnings, err)
+		return nil
+	}
+	return err
+}
+
+// Grid returns the section as a character map. All rows must have the same
+// width and consist of allowed bytes, unless allowed is empty.
+func (s Section) Grid(allowed string) ([][]byte, error) {
+	var grid [][]byte
+	for i, line := range s.Lines {
+		if len(line) == 0 {
+			continue
+		}
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			col := min(len(line), len(grid[0])) + 1
+			if err := s.Errorf(i, col, "expected row of width %d, got %d", len(grid[0]), len(line)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if allowed != "" {
+			if j := indexNotIn(line, allowed); j >= 0 {
+				if err := s.Errorf(i, j+1, "expected one of %q, got %q", allowed, line[j]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		grid = append(grid, []byte(line))
+	}
+	return grid, nil
+}
+
+// indexNotIn returns the index of the first byte of s not in allowed, or -1.
+func indexNotIn(s, allowed string) int {
+	for j := 0; j < len(s); j++ {
+		if strings.IndexByte(allowed, s[j]) < 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// Ints returns one integer per non-blank line.
+func (s Section) Ints() ([]int, error) {
+	var nums []int
+	for i, line := range s.Lines {
+		f, ok := firstField(line, 0, len(line))
+		if !ok {
+			continue
+		}
+		n, ok, err := s.int(i, f)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			nums = append(nums, n)
+		}
+	}
+	return nums, nil
+}
+
+// IntCSV returns the comma separated integers of every non-blank line.
+func (s Section) IntCSV() ([][]int, error) {
+	var rows [][]int
+	for i, line := range s.Lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		row, ok, err := s.ints(i, split(line, ','))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// Ranges returns every "a-b" range. Ranges are separated by commas or
+// newlines and a trailing comma is allowed.
+func (s Section) Ranges() ([]Range, error) {
+	var ranges []Range
+	for i, line := range s.Lines {
+		fields := split(line, ',')
+		for j, f := range fields {
+			if f.text == "" {
+				if j == len(fields)-1 {
+					continue // blank line or trailing comma
+				}
+				if err := s.Errorf(i, f.col, "expected range, got empty field"); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			dash := strings.IndexByte(f.text, '-')
+			if dash < 0 {
+				if err := s.Errorf(i, f.col, "expected range like \"3-5\", got %q", f.text); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			bounds, ok, err := s.ints(i, []field{
+				{text: f.text[:dash], col: f.col},
+				{text: f.text[dash+1:], col: f.col + dash + 1},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if bounds[0] > bounds[1] {
+				if err := s.Errorf(i, f.col, "range start %d is after end %d", bounds[0], bounds[1]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			ranges = append(ranges, Range{Start: bounds[0], End: bounds[1]})
+		}
+	}
+	return ranges, nil
+}
+
+// Points2D returns one "x,y" point per non-blank line.
+func (s Section) Points2D() ([][2]int, error) {
+	rows, err := s.points(2)
+	if err != nil {
+		return nil, err
+	}
+	points := make([][2]int, len(rows))
+	for i, row := range rows {
+		points[i] = [2]int(row)
+	}
+	return points, nil
+}
+
+// Points3D returns one "x,y,z" point per non-blank line.
+func (s Section) Points3D() ([][3]int, error) {
+	rows, err := s.points(3)
+	if err != nil {
+		return nil, err
+	}
+	points := make([][3]int, len(rows))
+	for i, row := range rows {
+		points[i] = [3]int(row)
+	}
+	return points, nil
+}
+
+// points reads comma separated rows that must have exactly dim values.
+func (s Section) points(dim int) ([][]int, error) {
+	var rows [][]int
+	for i, line := range s.Lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := split(line, ',')
+		if len(fields) != dim {
+			if err := s.Errorf(i, 0, "expected %d comma separated values, got %d", dim, len(fields)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		row, ok, err := s.ints(i, fields)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// field is a trimmed piece of a line together with its 1-based column.
+type field struct {
+	text string
+	col  int
+}
+
+// split cuts line at every sep and trims spaces around each field.
+func split(line string, sep byte) []field {
+	var fields []field
+	start := 0
+	for i := 0; i <= len(line); i++ {
+		if i == len(line) || line[i] == sep {
+			f, _ := firstField(line, start, i)
+			fields = append(fields, f)
+			start = i + 1
+		}
+	}
+	return fields
+}
+
+// firstField trims spaces from line[start:end]. It reports false if nothing is left.
+func firstField(line string, start, end int) (field, bool) {
+	for start < end && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	for end > start && (line[end-1] == ' ' || line[end-1] == '\t') {
+		end--
+	}
+	return field{text: line[start:end], col: start + 1}, start < end
+}
+
+// int parses a field of s.Lines[i] as a base 10 integer. It reports false
+// if the field was malformed and skipped in lenient mode.
+func (s Section) int(i int, f field) (int, bool, error) {
+	n, err := strconv.Atoi(f.text)
+	if err != nil {
+		return 0, false, s.Errorf(i, f.col, "expected integer, got %q", f.text)
+	}
+	return n, true, nil
+}
+
+// ints parses every field of s.Lines[i] as an integer. It reports false if
+// any of them was malformed and skipped in lenient mode.
+func (s Section) ints(i int, fields []field) ([]int, bool, error) {
+	nums := make([]int, len(fields))
+	for j, f := range fields {
+		n, ok, err := s.int(i, f)
+		if !ok {
+			return nil, false, err
+		}
+		nums[j] = n
+	}
+	return nums, true, nil
+}
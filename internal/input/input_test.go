@@ -0,0 +1,216 @@
+package input
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	lines, err := Lines(strings.NewReader("a\r\n\nb\n"))
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+
+	expected := []string{"a", "", "b"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
+
+func TestLinesLongLine(t *testing.T) {
+	long := strings.Repeat("1-2,", 100000)
+	lines, err := Lines(strings.NewReader(long))
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+	if len(lines) != 1 || len(lines[0]) != len(long) {
+		t.Errorf("Expected one line of %d bytes", len(long))
+	}
+}
+
+func TestEach(t *testing.T) {
+	var got []string
+	err := Each(&Source{R: strings.NewReader("1\r\nx\n3\n"), Name: "feed"}, func(s Section) error {
+		if _, err := s.Ints(); err != nil {
+			got = append(got, err.Error())
+			return nil
+		}
+		got = append(got, s.Lines[0])
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+	expected := []string{"1", `feed:2:1: expected integer, got "x"`, "3"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = Each(strings.NewReader("a\nb\n"), func(Section) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected to stop after the first line, got %v after %d calls", err, calls)
+	}
+}
+
+func TestSections(t *testing.T) {
+	sections, err := Sections(strings.NewReader("3-5\n10-14\n\n\n1\n5\n"))
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+
+	expected := []Section{
+		{Start: 1, Lines: []string{"3-5", "10-14"}},
+		{Start: 5, Lines: []string{"1", "5"}},
+	}
+	if len(sections) != len(expected) {
+		t.Fatalf("Expected %d sections, got %d", len(expected), len(sections))
+	}
+	for i, sec := range sections {
+		if sec.Start != expected[i].Start || !reflect.DeepEqual(sec.Lines, expected[i].Lines) {
+			t.Errorf("Section %d: expected %v, got %v", i, expected[i], sec)
+		}
+	}
+
+	ids, err := sections[1].Ints()
+	if err != nil {
+		t.Fatalf("Error reading ints: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 5}) {
+		t.Errorf("Expected [1 5], got %v", ids)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	grid, err := Grid(strings.NewReader("..@\n@.@\n\n"), ".@")
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+
+	expected := [][]byte{[]byte("..@"), []byte("@.@")}
+	if !reflect.DeepEqual(grid, expected) {
+		t.Errorf("Expected %q, got %q", expected, grid)
+	}
+}
+
+func TestRanges(t *testing.T) {
+	ranges, err := Ranges(strings.NewReader("11-22, 95-115,\n998-1012\n"))
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+
+	expected := []Range{{11, 22}, {95, 115}, {998, 1012}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("Expected %v, got %v", expected, ranges)
+	}
+}
+
+func TestIntCSV(t *testing.T) {
+	rows, err := IntCSV(strings.NewReader("1,2,3\n\n 4 , 5\n"))
+	if err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+
+	expected := [][]int{{1, 2, 3}, {4, 5}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, rows)
+	}
+}
+
+func TestPoints(t *testing.T) {
+	p2, err := Points2D(strings.NewReader("7,1\n11,1\n"))
+	if err != nil {
+		t.Fatalf("Error reading 2D: %v", err)
+	}
+	if !reflect.DeepEqual(p2, [][2]int{{7, 1}, {11, 1}}) {
+		t.Errorf("Unexpected 2D points %v", p2)
+	}
+
+	p3, err := Points3D(strings.NewReader("162,817,812\n"))
+	if err != nil {
+		t.Fatalf("Error reading 3D: %v", err)
+	}
+	if !reflect.DeepEqual(p3, [][3]int{{162, 817, 812}}) {
+		t.Errorf("Unexpected 3D points %v", p3)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		read     func(string) error
+		input    string
+		expected string
+	}{
+		{"int", func(s string) error { _, err := IntCSV(strings.NewReader(s)); return err }, "1,2\n3,1x\n", `2:3: expected integer, got "1x"`},
+		{"range dash", func(s string) error { _, err := Ranges(strings.NewReader(s)); return err }, "1-2,34", `1:5: expected range like "3-5", got "34"`},
+		{"range end", func(s string) error { _, err := Ranges(strings.NewReader(s)); return err }, "1-2,3-x", `1:7: expected integer, got "x"`},
+		{"range empty", func(s string) error { _, err := Ranges(strings.NewReader(s)); return err }, "1-2,,3-4", `1:5: expected range, got empty field`},
+		{"point dim", func(s string) error { _, err := Points3D(strings.NewReader(s)); return err }, "1,2,3\n\n1,2\n", `3: expected 3 comma separated values, got 2`},
+		{"range order", func(s string) error { _, err := Ranges(strings.NewReader(s)); return err }, "5-3", `1:1: range start 5 is after end 3`},
+		{"grid width", func(s string) error { _, err := Grid(strings.NewReader(s), ""); return err }, "...\n..\n", `2:3: expected row of width 3, got 2`},
+		{"grid cell", func(s string) error { _, err := Grid(strings.NewReader(s), ".@"); return err }, "..\n.x\n", `2:2: expected one of ".@", got 'x'`},
+	}
+
+	for _, tt := range tests {
+		err := tt.read(tt.input)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: expected *ParseError, got %v", tt.name, err)
+			continue
+		}
+		if err.Error() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, err.Error())
+		}
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	src := &Source{R: strings.NewReader("1,2,3\n4,5,1x\n"), Name: "input"}
+	_, err := Points3D(src)
+
+	expected := `input:2:5: expected integer, got "1x"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+}
+
+func TestLenient(t *testing.T) {
+	src := &Source{R: strings.NewReader("1,2,3\n4,5\n6,x,8\n9,10,11\n"), Name: "input", Lenient: true}
+	points, err := Points3D(src)
+	if err != nil {
+		t.Fatalf("Expected no error in lenient mode, got %v", err)
+	}
+
+	expected := [][3]int{{1, 2, 3}, {9, 10, 11}}
+	if !reflect.DeepEqual(points, expected) {
+		t.Errorf("Expected %v, got %v", expected, points)
+	}
+	if len(src.Warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %v", src.Warnings)
+	}
+
+	src = &Source{R: strings.NewReader("1-2,x-4,5-6"), Lenient: true}
+	ranges, err := Ranges(src)
+	if err != nil {
+		t.Fatalf("Expected no error in lenient mode, got %v", err)
+	}
+	if !reflect.DeepEqual(ranges, []Range{{1, 2}, {5, 6}}) || len(src.Warnings) != 1 {
+		t.Errorf("Unexpected ranges %v with warnings %v", ranges, src.Warnings)
+	}
+
+	src = &Source{R: strings.NewReader("@@@@@\n@@@\n@@x@@\n.@@@.\n"), Lenient: true}
+	grid, err := Grid(src, ".@")
+	if err != nil {
+		t.Fatalf("Expected no error in lenient mode, got %v", err)
+	}
+	if expected := [][]byte{[]byte("@@@@@"), []byte(".@@@.")}; !reflect.DeepEqual(grid, expected) || len(src.Warnings) != 2 {
+		t.Errorf("Unexpected grid %q with warnings %v", grid, src.Warnings)
+	}
+}
@@ -0,0 +1,177 @@
+// Package report writes solver results in a stable schema, either as the
+// human readable labels of each day or in a machine readable format.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{"text", "json", "csv", "tap"}
+
+// Result is the outcome of running one part against one input.
+type Result struct {
+	Day  int `json:"day"`
+	Part int `json:"part"`
+	// Label is the day's text for the part, e.g. "Part 2 - Timelines".
+	// Only the text format uses it.
+	Label string `json:"-"`
+	Input string `json:"input"`
+	// InputHash is the hex SHA-256 of the input file.
+	InputHash string `json:"input_sha256"`
+	// Answer is nil when the part failed, unless it was stopped early
+	// with a partial answer. Error is set in both cases.
+	Answer   *solver.Answer `json:"answer"`
+	Duration time.Duration  `json:"duration_ns"`
+	Error    string         `json:"error"`
+}
+
+// Writer writes results one at a time. Close finishes the output and must
+// be called even when nothing was written.
+type Writer interface {
+	Write(r Result) error
+	Close() error
+}
+
+// NewWriter returns a writer for format, one of Formats.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case "text":
+		return &TextWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "csv":
+		return newCSVWriter(w)
+	case "tap":
+		return &tapWriter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, ", "))
+}
+
+// TextWriter prints "label: answer" lines. Failed parts print nothing; the
+// caller reports their errors.
+type TextWriter struct {
+	w io.Writer
+	// DayHeaders prints a "Day NN" line before the first part of each day.
+	DayHeaders bool
+	lastDay    int
+}
+
+func (t *TextWriter) Write(r Result) error {
+	if t.DayHeaders && r.Day != t.lastDay {
+		if _, err := fmt.Fprintf(t.w, "Day %02d\n", r.Day); err != nil {
+			return err
+		}
+		t.lastDay = r.Day
+	}
+	if r.Error != "" {
+		return nil
+	}
+	_, err := fmt.Fprintf(t.w, "%s: %d\n", r.Label, r.Answer)
+	return err
+}
+
+func (t *TextWriter) Close() error { return nil }
+
+// jsonWriter writes a JSON array of results.
+type jsonWriter struct {
+	w       io.Writer
+	results []Result
+}
+
+func (j *jsonWriter) Write(r Result) error {
+	j.results = append(j.results, r)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	if j.results == nil {
+		j.results = []Result{}
+	}
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.results)
+}
+
+// csvHeader is the first row of the CSV format.
+var csvHeader = []string{"day", "part", "input", "input_sha256", "answer", "duration_ns", "error"}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	if err := c.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *csvWriter) Write(r Result) error {
+	answer := ""
+	if r.Answer != nil {
+		answer = r.Answer.String()
+	}
+	return c.w.Write([]string{
+		strconv.Itoa(r.Day),
+		strconv.Itoa(r.Part),
+		r.Input,
+		r.InputHash,
+		answer,
+		strconv.FormatInt(int64(r.Duration), 10),
+		r.Error,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// tapWriter writes the Test Anything Protocol, one test point per part
+// with the plan at the end.
+type tapWriter struct {
+	w       io.Writer
+	n       int
+	started bool
+}
+
+func (t *tapWriter) Write(r Result) error {
+	if !t.started {
+		if _, err := fmt.Fprintln(t.w, "TAP version 13"); err != nil {
+			return err
+		}
+		t.started = true
+	}
+	t.n++
+	if r.Error != "" {
+		partial := ""
+		if r.Answer != nil {
+			partial = fmt.Sprintf("  partial_answer: %s\n", r.Answer)
+		}
+		_, err := fmt.Fprintf(t.w, "not ok %d - day %02d part %d\n  ---\n  message: %s\n  input: %s\n%s  ...\n",
+			t.n, r.Day, r.Part, strconv.Quote(r.Error), r.Input, partial)
+		return err
+	}
+	_, err := fmt.Fprintf(t.w, "ok %d - day %02d part %d: %s # time=%v\n", t.n, r.Day, r.Part, r.Answer, r.Duration)
+	return err
+}
+
+func (t *tapWriter) Close() error {
+	if !t.started {
+		if _, err := fmt.Fprintln(t.w, "TAP version 13"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(t.w, "1..%d\n", t.n)
+	return err
+}
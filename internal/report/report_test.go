@@ -0,0 +1,117 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+func sample() []Result {
+	answer, partial := solver.Int(21), solver.Int(7)
+	return []Result{
+		{Day: 7, Part: 1, Label: "Part 1 - Beam splits", Input: "day07/input", InputHash: "abc", Answer: &answer, Duration: 1500 * time.Microsecond},
+		{Day: 8, Part: 2, Label: "Part 2 - Product", Input: "day08/input", InputHash: "def", Error: `day08/input:2:8: expected integer, got "1x"`},
+		{Day: 9, Part: 2, Label: "Part 2 - Area", Input: "day09/input", InputHash: "123", Answer: &partial, Duration: time.Second, Error: "interrupted"},
+	}
+}
+
+func write(t *testing.T, format string, configure func(Writer)) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("Error creating %s writer: %v", format, err)
+	}
+	if configure != nil {
+		configure(w)
+	}
+	for _, r := range sample() {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"text", "Part 1 - Beam splits: 21\n"},
+		{"csv", `day,part,input,input_sha256,answer,duration_ns,error
+7,1,day07/input,abc,21,1500000,
+8,2,day08/input,def,,0,"day08/input:2:8: expected integer, got ""1x"""
+9,2,day09/input,123,7,1000000000,interrupted
+`},
+		{"tap", `TAP version 13
+ok 1 - day 07 part 1: 21 # time=1.5ms
+not ok 2 - day 08 part 2
+  ---
+  message: "day08/input:2:8: expected integer, got \"1x\""
+  input: day08/input
+  ...
+not ok 3 - day 09 part 2
+  ---
+  message: "interrupted"
+  input: day09/input
+  partial_answer: 7
+  ...
+1..3
+`},
+	}
+	for _, tt := range tests {
+		if got := write(t, tt.format, nil); got != tt.expected {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tt.format, tt.expected, got)
+		}
+	}
+}
+
+func TestTextDayHeaders(t *testing.T) {
+	got := write(t, "text", func(w Writer) { w.(*TextWriter).DayHeaders = true })
+	expected := "Day 07\nPart 1 - Beam splits: 21\nDay 08\nDay 09\n"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var decoded []map[string]any
+	if err := json.Unmarshal([]byte(write(t, "json", nil)), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(decoded))
+	}
+	for _, key := range []string{"day", "part", "input", "input_sha256", "answer", "duration_ns", "error"} {
+		if _, ok := decoded[0][key]; !ok {
+			t.Errorf("Expected key %q in %v", key, decoded[0])
+		}
+	}
+	if decoded[0]["answer"] != "21" || decoded[1]["answer"] != nil || decoded[2]["answer"] != "7" {
+		t.Errorf("Expected answers \"21\", null and \"7\", got %v, %v and %v", decoded[0]["answer"], decoded[1]["answer"], decoded[2]["answer"])
+	}
+	if _, ok := decoded[0]["Label"]; ok {
+		t.Error("Expected the label to be left out")
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, "json")
+	w.Close()
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty array, got %q", buf.String())
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,134 @@
+// Package scaffold generates the skeleton of a new day.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// DaysFile is the file, relative to the repository root, that imports every
+// day into the aoc command.
+const DaysFile = "cmd/aoc/days.go"
+
+// files lists the files of a new day and the template that fills each one.
+// An empty template name creates an empty file.
+var files = []struct {
+	name     string
+	template string
+}{
+	{"main.go", "main.go.tmpl"},
+	{"main_test.go", "main_test.go.tmpl"},
+	{"input", ""},
+	{"input_test", ""},
+	{"story.md", ""},
+}
+
+// dayDirRe matches the day directories.
+var dayDirRe = regexp.MustCompile(`^day\d\d$`)
+
+// Generate creates the files for day below root and adds the day to the aoc
+// command. It returns the paths it wrote. Existing non-empty files are never
+// overwritten; if any are found nothing is written at all.
+func Generate(root string, day int) ([]string, error) {
+	if day < 1 || day > 25 {
+		return nil, fmt.Errorf("day %d is out of range 1-25", day)
+	}
+	dir := filepath.Join(root, solver.Dir(day))
+
+	var existing []string
+	for _, f := range files {
+		if info, err := os.Stat(filepath.Join(dir, f.name)); err == nil && info.Size() > 0 {
+			existing = append(existing, filepath.Join(dir, f.name))
+		}
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("refusing to overwrite non-empty files: %s", strings.Join(existing, ", "))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Day     int
+		Package string
+	}{day, solver.Dir(day)}
+
+	var written []string
+	for _, f := range files {
+		var content []byte
+		if f.template != "" {
+			var err error
+			if content, err = render(f.template, data); err != nil {
+				return written, err
+			}
+		}
+		filename := filepath.Join(dir, f.name)
+		if err := os.WriteFile(filename, content, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, filename)
+	}
+
+	daysFile, err := UpdateDays(root)
+	if err != nil {
+		return written, err
+	}
+	return append(written, daysFile), nil
+}
+
+// UpdateDays rewrites DaysFile so it imports every dayNN directory that has
+// a main.go.
+func UpdateDays(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+
+	var days []string
+	for _, e := range entries {
+		if !e.IsDir() || !dayDirRe.MatchString(e.Name()) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, e.Name(), "main.go")); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		days = append(days, e.Name())
+	}
+	sort.Strings(days)
+
+	content, err := render("days.go.tmpl", days)
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(root, DaysFile)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", err
+	}
+	return filename, os.WriteFile(filename, content, 0o644)
+}
+
+// render executes a template and gofmts the result.
+func render(name string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,92 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "day01"), 0o755)
+	os.WriteFile(filepath.Join(root, "day01", "main.go"), []byte("package day01\n"), 0o644)
+
+	// An empty placeholder left by the old Makefile target is filled in.
+	os.MkdirAll(filepath.Join(root, "day10"), 0o755)
+	os.WriteFile(filepath.Join(root, "day10", "main.go"), nil, 0o644)
+
+	written, err := Generate(root, 10)
+	if err != nil {
+		t.Fatalf("Error generating: %v", err)
+	}
+	if len(written) != 6 {
+		t.Errorf("Expected 6 files, got %v", written)
+	}
+
+	checks := map[string][]string{
+		"day10/main.go":      {"package day10\n", "Number: 10,", "func solvePart2(filename string)"},
+		"day10/main_test.go": {"package day10\n", "func TestSolverWithInlineInput", "func BenchmarkPart1"},
+		DaysFile:             {"\"github.com/abtris/aoc2025/day01\"\n\t_ \"github.com/abtris/aoc2025/day10\""},
+	}
+	for name, wants := range checks {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s: expected to contain %q, got:\n%s", name, want, data)
+			}
+		}
+	}
+
+	for _, name := range []string{"input", "input_test", "story.md"} {
+		if info, err := os.Stat(filepath.Join(root, "day10", name)); err != nil || info.Size() != 0 {
+			t.Errorf("Expected empty %s, got %v", name, err)
+		}
+	}
+}
+
+func TestGenerateRefusesToOverwrite(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "day03"), 0o755)
+	os.WriteFile(filepath.Join(root, "day03", "story.md"), []byte("--- Day 3 ---\n"), 0o644)
+
+	if _, err := Generate(root, 3); err == nil || !strings.Contains(err.Error(), "story.md") {
+		t.Errorf("Expected refusal naming story.md, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "day03", "main.go")); err == nil {
+		t.Error("Expected nothing to be written")
+	}
+
+	if _, err := Generate(root, 26); err == nil {
+		t.Error("Expected error for day 26")
+	}
+}
+
+// TestDaysFileUpToDate makes sure the aoc command imports every day.
+func TestDaysFileUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	current, err := os.ReadFile(filepath.Join(root, DaysFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := t.TempDir()
+	entries, _ := os.ReadDir(root)
+	for _, e := range entries {
+		if dayDirRe.MatchString(e.Name()) {
+			os.MkdirAll(filepath.Join(tmp, e.Name()), 0o755)
+			os.WriteFile(filepath.Join(tmp, e.Name(), "main.go"), nil, 0o644)
+		}
+	}
+	filename, err := UpdateDays(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := os.ReadFile(filename)
+	if string(current) != string(expected) {
+		t.Errorf("%s is out of date, expected:\n%s", DaysFile, expected)
+	}
+}
@@ -0,0 +1,78 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Fetcher downloads puzzle inputs into the day directories, keeping a copy
+// in a cache directory. It never downloads an input that is already present.
+type Fetcher struct {
+	Client   *Client
+	CacheDir string
+}
+
+// FetchResult says where an input came from.
+type FetchResult int
+
+const (
+	Existing   FetchResult = iota // dest already had content
+	FromCache                     // copied from the cache
+	Downloaded                    // fetched from the site
+)
+
+func (r FetchResult) String() string {
+	switch r {
+	case Existing:
+		return "already present"
+	case FromCache:
+		return "copied from cache"
+	case Downloaded:
+		return "downloaded"
+	}
+	return fmt.Sprintf("FetchResult(%d)", int(r))
+}
+
+// cacheFile returns the cached input path for a day.
+func (f *Fetcher) cacheFile(day int) string {
+	return filepath.Join(f.CacheDir, fmt.Sprintf("day%02d.input", day))
+}
+
+// Fetch makes sure dest holds the input for day. An empty dest, such as the
+// placeholder created by the scaffold, counts as missing.
+func (f *Fetcher) Fetch(ctx context.Context, day int, dest string) (FetchResult, error) {
+	if nonEmpty(dest) {
+		return Existing, nil
+	}
+
+	if data, err := os.ReadFile(f.cacheFile(day)); err == nil && len(data) > 0 {
+		return FromCache, writeFile(dest, data)
+	}
+
+	data, err := f.Client.Input(ctx, day)
+	if err != nil {
+		return Downloaded, err
+	}
+	if err := writeFile(f.cacheFile(day), data); err != nil {
+		return Downloaded, err
+	}
+	return Downloaded, writeFile(dest, data)
+}
+
+// nonEmpty reports whether filename exists and has content.
+func nonEmpty(filename string) bool {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return false
+	}
+	return info.Size() > 0
+}
+
+func writeFile(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
@@ -0,0 +1,123 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"slices"
+	"time"
+)
+
+// Guesses records what has been submitted for each part so the same wrong
+// answer is never sent twice and cooldowns survive between runs.
+type Guesses map[string]*PartGuesses
+
+// PartGuesses is the submission history of one part.
+type PartGuesses struct {
+	Correct   string    `json:"correct,omitempty"`
+	Wrong     []string  `json:"wrong,omitempty"`
+	High      string    `json:"high,omitempty"` // lowest answer reported too high
+	Low       string    `json:"low,omitempty"`  // highest answer reported too low
+	WaitUntil time.Time `json:"wait_until,omitzero"`
+}
+
+// guessKey names a part in the Guesses file, e.g. "day07/2".
+func guessKey(day, part int) string {
+	return fmt.Sprintf("day%02d/%d", day, part)
+}
+
+// LoadGuesses reads the submission history. A missing file is empty.
+func LoadGuesses(filename string) (Guesses, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Guesses{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	g := Guesses{}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return g, nil
+}
+
+// Save writes the submission history.
+func (g Guesses) Save(filename string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, append(data, '\n'))
+}
+
+// Part returns the history for a part, creating it if needed.
+func (g Guesses) Part(day, part int) *PartGuesses {
+	key := guessKey(day, part)
+	if g[key] == nil {
+		g[key] = &PartGuesses{}
+	}
+	return g[key]
+}
+
+// Check returns an error if answer should not be submitted at now: the part
+// is solved, the answer was already rejected, it lies outside the known
+// too high/too low bounds, or the cooldown has not passed.
+func (p *PartGuesses) Check(answer string, now time.Time) error {
+	switch {
+	case p.Correct != "":
+		return fmt.Errorf("already solved with %s", p.Correct)
+	case slices.Contains(p.Wrong, answer):
+		return fmt.Errorf("%s was already rejected", answer)
+	case p.High != "" && compare(answer, p.High) >= 0:
+		return fmt.Errorf("%s is not below %s, which was too high", answer, p.High)
+	case p.Low != "" && compare(answer, p.Low) <= 0:
+		return fmt.Errorf("%s is not above %s, which was too low", answer, p.Low)
+	case now.Before(p.WaitUntil):
+		return fmt.Errorf("cooldown: wait %s before submitting again", p.WaitUntil.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// Record updates the history with the response to submitting answer at now.
+func (p *PartGuesses) Record(answer string, resp Response, now time.Time) {
+	switch resp.Verdict {
+	case Correct:
+		p.Correct = answer
+	case TooHigh:
+		if p.High == "" || compare(answer, p.High) < 0 {
+			p.High = answer
+		}
+	case TooLow:
+		if p.Low == "" || compare(answer, p.Low) > 0 {
+			p.Low = answer
+		}
+	}
+	if resp.Verdict.IsWrong() && !slices.Contains(p.Wrong, answer) {
+		p.Wrong = append(p.Wrong, answer)
+	}
+	if resp.Wait > 0 {
+		p.WaitUntil = now.Add(resp.Wait)
+	}
+}
+
+// compare orders two decimal answers numerically. Non-numeric answers
+// compare as strings.
+func compare(a, b string) int {
+	x, okx := new(big.Int).SetString(a, 10)
+	y, oky := new(big.Int).SetString(b, 10)
+	if !okx || !oky {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return x.Cmp(y)
+}
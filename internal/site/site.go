@@ -0,0 +1,142 @@
+// Package site talks to the Advent of Code website.
+//
+// All requests go through a Doer so tests can point the client at an
+// httptest server instead of the real site.
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Year is the event the solvers in this repository belong to.
+const Year = 2025
+
+// DefaultBaseURL is the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// SessionEnv is the environment variable holding the session cookie.
+const SessionEnv = "AOC_SESSION"
+
+// userAgent identifies automated requests as the site asks tools to do.
+const userAgent = "github.com/abtris/aoc2025 (aoc command)"
+
+// ErrNoSession is returned when no session token is configured.
+var ErrNoSession = errors.New("no session token: set " + SessionEnv + " or write it to the session config file")
+
+// Doer sends HTTP requests. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is an authenticated Advent of Code client.
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    Doer
+
+	// Throttle spaces out requests; nil disables rate limiting.
+	Throttle *Throttle
+}
+
+// NewClient returns a client for the real site with the default throttle.
+func NewClient(session string) *Client {
+	return &Client{
+		BaseURL:  DefaultBaseURL,
+		Session:  session,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+		Throttle: &Throttle{File: filepath.Join(CacheDir(), "last-request"), Interval: DefaultInterval},
+	}
+}
+
+// SessionFile returns the config file the session token is read from when
+// the environment variable is not set.
+func SessionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc", "session")
+}
+
+// CacheDir returns the directory downloaded inputs are cached in.
+func CacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "aoc", fmt.Sprint(Year))
+}
+
+// LoadSession returns the session token from the environment or, failing
+// that, from the session config file.
+func LoadSession() (string, error) {
+	if s := strings.TrimSpace(os.Getenv(SessionEnv)); s != "" {
+		return s, nil
+	}
+	if file := SessionFile(); file != "" {
+		data, err := os.ReadFile(file)
+		if err == nil && strings.TrimSpace(string(data)) != "" {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", ErrNoSession
+}
+
+// newRequest builds an authenticated request for a path below BaseURL.
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
+}
+
+// do waits for the throttle and sends req. Non-200 responses are errors.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	if c.Throttle != nil {
+		if err := c.Throttle.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, msg)
+	}
+	return body, nil
+}
+
+// Input downloads the puzzle input for a day.
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", Year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
@@ -0,0 +1,134 @@
+package site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client talking to a stand-in server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{BaseURL: server.URL, Session: "secret", HTTP: server.Client()}
+}
+
+func TestInput(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2025/day/7/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if !strings.Contains(r.UserAgent(), "aoc2025") {
+			http.Error(w, "missing user agent", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(".......S.......\n"))
+	})
+
+	data, err := client.Input(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Error fetching: %v", err)
+	}
+	if string(data) != ".......S.......\n" {
+		t.Errorf("Unexpected input %q", data)
+	}
+
+	client.Session = "wrong"
+	if _, err := client.Input(context.Background(), 7); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Expected 400 error, got %v", err)
+	}
+
+	client.Session = ""
+	if _, err := client.Input(context.Background(), 7); err != ErrNoSession {
+		t.Errorf("Expected ErrNoSession, got %v", err)
+	}
+}
+
+func TestFetchNeverRedownloads(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("L68\nL30\n"))
+	})
+
+	dir := t.TempDir()
+	fetcher := &Fetcher{Client: client, CacheDir: filepath.Join(dir, "cache")}
+	dest := filepath.Join(dir, "day01", "input")
+
+	// An empty placeholder counts as missing.
+	os.MkdirAll(filepath.Dir(dest), 0o755)
+	os.WriteFile(dest, nil, 0o644)
+
+	steps := []struct {
+		prepare  func()
+		expected FetchResult
+	}{
+		{func() {}, Downloaded},
+		{func() {}, Existing},
+		{func() { os.Remove(dest) }, FromCache},
+	}
+
+	for i, step := range steps {
+		step.prepare()
+		result, err := fetcher.Fetch(context.Background(), 1, dest)
+		if err != nil {
+			t.Fatalf("step %d: error fetching: %v", i, err)
+		}
+		if result != step.expected {
+			t.Errorf("step %d: expected %v, got %v", i, step.expected, result)
+		}
+		if data, _ := os.ReadFile(dest); string(data) != "L68\nL30\n" {
+			t.Errorf("step %d: unexpected content %q", i, data)
+		}
+	}
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected exactly 1 request, got %d", n)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var slept []time.Duration
+	throttle := &Throttle{
+		File:     filepath.Join(t.TempDir(), "last-request"),
+		Interval: 5 * time.Second,
+		Now:      func() time.Time { return now },
+		Sleep: func(ctx context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			now = now.Add(d)
+			return nil
+		},
+	}
+
+	ctx := context.Background()
+	throttle.Wait(ctx) // first request never waits
+	now = now.Add(2 * time.Second)
+	throttle.Wait(ctx) // 3s left of the interval
+	now = now.Add(10 * time.Second)
+	throttle.Wait(ctx) // interval already passed
+
+	if len(slept) != 1 || slept[0] != 3*time.Second {
+		t.Errorf("Expected a single 3s wait, got %v", slept)
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	t.Setenv(SessionEnv, " abc123\n")
+	session, err := LoadSession()
+	if err != nil || session != "abc123" {
+		t.Errorf("Expected abc123, got %q (%v)", session, err)
+	}
+}
@@ -0,0 +1,120 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the site's judgement of a submitted answer.
+type Verdict int
+
+const (
+	Unknown       Verdict = iota // the response could not be understood
+	Correct                      // "That's the right answer!"
+	Wrong                        // "That's not the right answer."
+	TooHigh                      // wrong, and "your answer is too high"
+	TooLow                       // wrong, and "your answer is too low"
+	TooSoon                      // "You gave an answer too recently"
+	AlreadySolved                // "You don't seem to be solving the right level"
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case TooSoon:
+		return "too soon"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// IsWrong reports whether the answer was rejected as incorrect.
+func (v Verdict) IsWrong() bool {
+	return v == Wrong || v == TooHigh || v == TooLow
+}
+
+// Response is a parsed answer submission response.
+type Response struct {
+	Verdict Verdict
+	Wait    time.Duration // cooldown before the next submission, if given
+	Message string        // the plain text of the response article
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]+>`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+	leftRe    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	minutesRe = regexp.MustCompile(`(?i)wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseResponse interprets the HTML page returned after posting an answer.
+func ParseResponse(page string) Response {
+	text := page
+	if m := articleRe.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(tagRe.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(spaceRe.ReplaceAllString(text, " "))
+
+	resp := Response{Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		resp.Verdict = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		resp.Verdict = Wrong
+		if strings.Contains(text, "answer is too high") {
+			resp.Verdict = TooHigh
+		} else if strings.Contains(text, "answer is too low") {
+			resp.Verdict = TooLow
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		resp.Verdict = TooSoon
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		resp.Verdict = AlreadySolved
+	}
+
+	if m := leftRe.FindStringSubmatch(text); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		resp.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if m := minutesRe.FindStringSubmatch(text); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		resp.Wait = time.Duration(minutes) * time.Minute
+	}
+	return resp
+}
+
+// Submit posts an answer for one part of a day.
+func (c *Client) Submit(ctx context.Context, day, part int, answer string) (Response, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return Response{}, err
+	}
+	return ParseResponse(string(body)), nil
+}
@@ -0,0 +1,109 @@
+package site
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// page wraps a response message the way the site does.
+func page(msg string) string {
+	return `<!DOCTYPE html><html><body><main><article><p>` + msg + `</p></article></main></body></html>`
+}
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{page(`That's the right answer!  You are <span class="day-success">one gold star</span> closer.`), Correct, 0},
+		{page(`That's not the right answer; your answer is too high.  Please wait one minute before trying again. [<a href="/2025/day/7">Return to Day 7</a>]`), TooHigh, time.Minute},
+		{page(`That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.`), TooLow, 5 * time.Minute},
+		{page(`That's not the right answer.  If you're stuck, make sure you're using the full input data.`), Wrong, 0},
+		{page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 37s left to wait.`), TooSoon, 4*time.Minute + 37*time.Second},
+		{page(`You gave an answer too recently.  You have 12s left to wait.`), TooSoon, 12 * time.Second},
+		{page(`You don't seem to be solving the right level.  Did you already complete it?`), AlreadySolved, 0},
+		{`<html>maintenance</html>`, Unknown, 0},
+	}
+
+	for _, tt := range tests {
+		resp := ParseResponse(tt.page)
+		if resp.Verdict != tt.verdict || resp.Wait != tt.wait {
+			t.Errorf("ParseResponse(%q) = %v %v, expected %v %v", resp.Message, resp.Verdict, resp.Wait, tt.verdict, tt.wait)
+		}
+	}
+}
+
+func TestGuesses(t *testing.T) {
+	now := time.Unix(1000, 0)
+	filename := filepath.Join(t.TempDir(), "guesses.json")
+	guesses, _ := LoadGuesses(filename)
+
+	p := guesses.Part(7, 2)
+	p.Record("500", Response{Verdict: TooHigh, Wait: time.Minute}, now)
+	p.Record("100", Response{Verdict: TooLow}, now)
+	if err := guesses.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadGuesses(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p = loaded.Part(7, 2)
+
+	tests := []struct {
+		answer string
+		at     time.Time
+		ok     bool
+	}{
+		{"300", now, false},                    // still in cooldown
+		{"300", now.Add(time.Minute), true},    // cooldown over
+		{"500", now.Add(time.Hour), false},     // already rejected
+		{"600", now.Add(time.Hour), false},     // above a too high answer
+		{"99", now.Add(time.Hour), false},      // below a too low answer
+		{"1000000", now.Add(time.Hour), false}, // numeric, not string, order
+		{"101", now.Add(time.Hour), true},
+	}
+	for _, tt := range tests {
+		if err := p.Check(tt.answer, tt.at); (err == nil) != tt.ok {
+			t.Errorf("Check(%s) = %v, expected ok=%v", tt.answer, err, tt.ok)
+		}
+	}
+
+	p.Record("300", Response{Verdict: Correct}, now)
+	if err := p.Check("301", now.Add(time.Hour)); err == nil {
+		t.Error("Expected solved part to refuse submissions")
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2025/day/7/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" {
+			http.Error(w, "bad level", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("answer") == "40" {
+			w.Write([]byte(page(`That's the right answer!`)))
+			return
+		}
+		w.Write([]byte(page(`That's not the right answer; your answer is too low.  Please wait one minute before trying again.`)))
+	})
+
+	resp, err := client.Submit(context.Background(), 7, 2, "40")
+	if err != nil || resp.Verdict != Correct {
+		t.Errorf("Expected correct, got %v (%v)", resp.Verdict, err)
+	}
+	resp, err = client.Submit(context.Background(), 7, 2, "39")
+	if err != nil || resp.Verdict != TooLow || !strings.Contains(resp.Message, "too low") {
+		t.Errorf("Expected too low, got %v %q (%v)", resp.Verdict, resp.Message, err)
+	}
+}
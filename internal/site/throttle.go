@@ -0,0 +1,84 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is the minimum time between two requests to the site.
+const DefaultInterval = 5 * time.Second
+
+// Throttle enforces a minimum interval between requests. The time of the
+// last request is kept in File so separate aoc invocations share the limit.
+type Throttle struct {
+	File     string
+	Interval time.Duration
+
+	// Now and Sleep default to the real clock; tests replace them.
+	Now   func() time.Time
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+func (t *Throttle) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+func (t *Throttle) sleep(ctx context.Context, d time.Duration) error {
+	if t.Sleep != nil {
+		return t.Sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until a request may be sent and records it as sent.
+func (t *Throttle) Wait(ctx context.Context) error {
+	last, err := t.last()
+	if err != nil {
+		return err
+	}
+	if wait := last.Add(t.Interval).Sub(t.now()); wait > 0 {
+		if err := t.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+	return t.record(t.now())
+}
+
+// last returns the time of the previous request, or the zero time.
+func (t *Throttle) last() (time.Time, error) {
+	data, err := os.ReadFile(t.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	nanos, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}, nil // a corrupt file only means we do not wait
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func (t *Throttle) record(at time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(t.File), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.File, []byte(strconv.FormatInt(at.UnixNano(), 10)), 0o644)
+}
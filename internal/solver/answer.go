@@ -0,0 +1,145 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Answer is the result of one puzzle part. Values that fit are kept as an
+// int64; arithmetic that would overflow promotes the result to a *big.Int
+// instead of silently wrapping. The zero value is 0.
+type Answer struct {
+	n   int64
+	big *big.Int // non-nil only when the value does not fit in an int64
+}
+
+// Integer is the set of integer types Int accepts.
+type Integer interface {
+	~int | ~int32 | ~int64
+}
+
+// Int returns an Answer holding n.
+func Int[T Integer](n T) Answer {
+	return Answer{n: int64(n)}
+}
+
+// BigInt returns an Answer holding a copy of b.
+func BigInt(b *big.Int) Answer {
+	if b.IsInt64() {
+		return Answer{n: b.Int64()}
+	}
+	return Answer{big: new(big.Int).Set(b)}
+}
+
+// Int64 returns the value and whether it fits in an int64.
+func (a Answer) Int64() (int64, bool) {
+	return a.n, a.big == nil
+}
+
+// IsBig reports whether the value needed arbitrary precision.
+func (a Answer) IsBig() bool {
+	return a.big != nil
+}
+
+// Big returns the value as a new *big.Int.
+func (a Answer) Big() *big.Int {
+	if a.big != nil {
+		return new(big.Int).Set(a.big)
+	}
+	return big.NewInt(a.n)
+}
+
+// Add returns a + b, promoting to big arithmetic on overflow.
+func (a Answer) Add(b Answer) Answer {
+	if a.big == nil && b.big == nil {
+		sum := a.n + b.n
+		if (sum > a.n) == (b.n > 0) {
+			return Answer{n: sum}
+		}
+	}
+	return BigInt(new(big.Int).Add(a.Big(), b.Big()))
+}
+
+// Mul returns a * b, promoting to big arithmetic on overflow.
+func (a Answer) Mul(b Answer) Answer {
+	if a.big == nil && b.big == nil {
+		if a.n == 0 || b.n == 0 {
+			return Answer{}
+		}
+		product := a.n * b.n
+		overflow := product/b.n != a.n ||
+			(a.n == -1 && b.n == math.MinInt64) ||
+			(b.n == -1 && a.n == math.MinInt64)
+		if !overflow {
+			return Answer{n: product}
+		}
+	}
+	return BigInt(new(big.Int).Mul(a.Big(), b.Big()))
+}
+
+// Cmp compares a and b and returns -1, 0 or +1.
+func (a Answer) Cmp(b Answer) int {
+	if a.big == nil && b.big == nil {
+		switch {
+		case a.n < b.n:
+			return -1
+		case a.n > b.n:
+			return 1
+		}
+		return 0
+	}
+	return a.Big().Cmp(b.Big())
+}
+
+// Equal reports whether a and b hold the same value.
+func (a Answer) Equal(b Answer) bool {
+	return a.Cmp(b) == 0
+}
+
+// String returns the decimal representation.
+func (a Answer) String() string {
+	if a.big != nil {
+		return a.big.String()
+	}
+	return strconv.FormatInt(a.n, 10)
+}
+
+// Format makes Answer print like an integer with %d, %v and %s.
+func (a Answer) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		fmt.Fprint(f, a.String())
+	default:
+		a.Big().Format(f, verb)
+	}
+}
+
+// Parse reads a decimal Answer of any size.
+func Parse(s string) (Answer, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Int(n), nil
+	}
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Answer{}, fmt.Errorf("invalid answer %q", s)
+	}
+	return BigInt(b), nil
+}
+
+// MarshalText encodes the answer as a decimal string, so JSON stores big
+// values without losing precision.
+func (a Answer) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes a decimal string.
+func (a *Answer) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,103 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestAnswerAdd(t *testing.T) {
+	tests := []struct {
+		a, b     Answer
+		expected string
+		big      bool
+	}{
+		{Int(2), Int(3), "5", false},
+		{Int(-2), Int(3), "1", false},
+		{Int(math.MaxInt64), Int(1), "9223372036854775808", true},
+		{Int(math.MinInt64), Int(-1), "-9223372036854775809", true},
+		{Int(math.MaxInt64).Add(Int(1)), Int(-1), "9223372036854775807", false},
+	}
+
+	for _, tt := range tests {
+		result := tt.a.Add(tt.b)
+		if result.String() != tt.expected || result.IsBig() != tt.big {
+			t.Errorf("%v + %v = %v (big %v), expected %s (big %v)", tt.a, tt.b, result, result.IsBig(), tt.expected, tt.big)
+		}
+	}
+}
+
+func TestAnswerMul(t *testing.T) {
+	tests := []struct {
+		a, b     Answer
+		expected string
+		big      bool
+	}{
+		{Int(6), Int(7), "42", false},
+		{Int(0), Int(math.MaxInt64), "0", false},
+		{Int(-1), Int(math.MinInt64), "9223372036854775808", true},
+		{Int(math.MinInt64), Int(-1), "9223372036854775808", true},
+		{Int(1 << 40), Int(1 << 40), "1208925819614629174706176", true},
+		{Int(3037000499), Int(3037000499), "9223372030926249001", false},
+	}
+
+	for _, tt := range tests {
+		result := tt.a.Mul(tt.b)
+		if result.String() != tt.expected || result.IsBig() != tt.big {
+			t.Errorf("%v * %v = %v (big %v), expected %s (big %v)", tt.a, tt.b, result, result.IsBig(), tt.expected, tt.big)
+		}
+	}
+}
+
+func TestAnswerCmp(t *testing.T) {
+	huge := Int(math.MaxInt64).Add(Int(1))
+	if Int(1).Cmp(Int(2)) != -1 || Int(2).Cmp(Int(1)) != 1 || !Int(3).Equal(Int(3)) {
+		t.Error("Unexpected int64 comparison")
+	}
+	if huge.Cmp(Int(math.MaxInt64)) != 1 || Int(0).Cmp(huge) != -1 {
+		t.Error("Unexpected big comparison")
+	}
+	if !BigInt(big.NewInt(7)).Equal(Int(7)) || BigInt(big.NewInt(7)).IsBig() {
+		t.Error("Expected BigInt to normalise small values")
+	}
+}
+
+func TestAnswerFormat(t *testing.T) {
+	huge := Int(math.MaxInt64).Mul(Int(10))
+	tests := []struct {
+		format   string
+		answer   Answer
+		expected string
+	}{
+		{"%d", Int(42), "42"},
+		{"%v", Int(-7), "-7"},
+		{"%s", huge, "92233720368547758070"},
+		{"%d", huge, "92233720368547758070"},
+		{"%5d", Int(42), "   42"},
+		{"%v", Answer{}, "0"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.answer); got != tt.expected {
+			t.Errorf("Sprintf(%q) = %q, expected %q", tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestAnswerText(t *testing.T) {
+	for _, s := range []string{"0", "-42", "92233720368547758070"} {
+		var a Answer
+		if err := a.UnmarshalText([]byte(s)); err != nil {
+			t.Fatalf("UnmarshalText(%q) returned error: %v", s, err)
+		}
+		text, _ := a.MarshalText()
+		if string(text) != s {
+			t.Errorf("Round trip of %q gave %q", s, text)
+		}
+	}
+
+	if _, err := Parse("12x"); err == nil {
+		t.Error("Expected error for malformed answer")
+	}
+}
@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Param is a tunable value of a solver, such as the number of connections
+// day 8 makes in part 1.
+type Param struct {
+	Name    string
+	Default int
+	// Min is the smallest accepted value.
+	Min   int
+	Usage string
+}
+
+// Values holds parameter values by name.
+type Values map[string]int
+
+// Configurable is implemented by solvers with tunable parameters.
+type Configurable interface {
+	// Params lists the parameters with their defaults.
+	Params() []Param
+	// WithParams returns a solver that uses values, which holds every
+	// parameter. It reports combinations of values that make no sense.
+	WithParams(values Values) (Solver, error)
+}
+
+// Params returns the parameters of the day's solver, if it has any.
+func (d Day) Params() []Param {
+	if c, ok := d.Solver.(Configurable); ok {
+		return c.Params()
+	}
+	return nil
+}
+
+// Configure returns d with its solver set up from values. Parameters not in
+// values keep their defaults. Unknown names and values below a
+// parameter's minimum are errors.
+func (d Day) Configure(values Values) (Day, error) {
+	c, ok := d.Solver.(Configurable)
+	if !ok {
+		if len(values) > 0 {
+			return d, fmt.Errorf("day %d has no parameters", d.Number)
+		}
+		return d, nil
+	}
+
+	params := c.Params()
+	full := make(Values, len(params))
+	byName := make(map[string]Param, len(params))
+	for _, p := range params {
+		full[p.Name] = p.Default
+		byName[p.Name] = p
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p, ok := byName[name]
+		if !ok {
+			known := make([]string, len(params))
+			for i, p := range params {
+				known[i] = p.Name
+			}
+			return d, fmt.Errorf("day %d has no parameter %q (known: %s)", d.Number, name, strings.Join(known, ", "))
+		}
+		if values[name] < p.Min {
+			return d, fmt.Errorf("day %d: %s must be at least %d, got %d", d.Number, name, p.Min, values[name])
+		}
+		full[name] = values[name]
+	}
+
+	s, err := c.WithParams(full)
+	if err != nil {
+		return d, fmt.Errorf("day %d: %w", d.Number, err)
+	}
+	d.Solver = s
+	return d, nil
+}
@@ -0,0 +1,43 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+)
+
+// Progress receives how much of its work a long running part has done.
+// The unit is up to the part, e.g. ranges processed or pairs checked.
+type Progress func(done, total int64)
+
+type progressKey struct{}
+
+// WithProgress returns a context that carries fn to the parts run with it.
+func WithProgress(ctx context.Context, fn Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress passes done and total to the callback carried by ctx, if
+// there is one. Parts should call it often enough for a progress bar but
+// not in their innermost loop.
+func ReportProgress(ctx context.Context, done, total int64) {
+	if fn, ok := ctx.Value(progressKey{}).(Progress); ok && fn != nil {
+		fn(done, total)
+	}
+}
+
+// PartialError is returned by a part that was stopped before it finished.
+// Answer is the best result found in the work that was done.
+type PartialError struct {
+	Answer      Answer
+	Done, Total int64
+	Unit        string
+	Err         error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("stopped after %d of %d %s with partial answer %v: %v", e.Done, e.Total, e.Unit, e.Answer, e.Err)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,148 @@
+// Package solver holds the Solver interface and the registry that every
+// dayNN package plugs into.
+package solver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Solver is implemented by every day. Both parts read the puzzle input from r.
+type Solver interface {
+	Part1(r io.Reader) (Answer, error)
+	Part2(r io.Reader) (Answer, error)
+}
+
+// ContextSolver is implemented by solvers whose parts can be cancelled,
+// like the Context variants of database/sql drivers. Long running loops
+// check ctx and return its error once it is done.
+type ContextSolver interface {
+	Part1Context(ctx context.Context, r io.Reader) (Answer, error)
+	Part2Context(ctx context.Context, r io.Reader) (Answer, error)
+}
+
+// PartFunc solves one half of a puzzle.
+type PartFunc func(r io.Reader) (Answer, error)
+
+// ContextPartFunc solves one half of a puzzle until ctx is done.
+type ContextPartFunc func(ctx context.Context, r io.Reader) (Answer, error)
+
+// SolveFile opens filename and feeds it to part.
+func SolveFile(part PartFunc, filename string) (Answer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Answer{}, err
+	}
+	defer file.Close()
+
+	return part(file)
+}
+
+// Day describes a single puzzle day and its solver.
+type Day struct {
+	Number int
+	Solver Solver
+
+	// Labels are the human readable result prefixes, e.g. "Part 2 - Timelines"
+	Labels [2]string
+}
+
+// Part returns the solver and label for part 1 or 2.
+func (d Day) Part(n int) (PartFunc, string, error) {
+	switch n {
+	case 1:
+		return d.Solver.Part1, d.Labels[0], nil
+	case 2:
+		return d.Solver.Part2, d.Labels[1], nil
+	}
+	return nil, "", fmt.Errorf("day %d has no part %d", d.Number, n)
+}
+
+// PartContext is like Part but the returned function honours ctx. Parts of
+// solvers that are not a ContextSolver run in their own goroutine, which is
+// abandoned when ctx is done first.
+func (d Day) PartContext(n int) (ContextPartFunc, string, error) {
+	if cs, ok := d.Solver.(ContextSolver); ok {
+		switch n {
+		case 1:
+			return cs.Part1Context, d.Labels[0], nil
+		case 2:
+			return cs.Part2Context, d.Labels[1], nil
+		}
+	}
+
+	part, label, err := d.Part(n)
+	if err != nil {
+		return nil, "", err
+	}
+	return func(ctx context.Context, r io.Reader) (Answer, error) {
+		if err := ctx.Err(); err != nil {
+			return Answer{}, err
+		}
+		type result struct {
+			answer Answer
+			err    error
+		}
+		done := make(chan result, 1)
+		go func() {
+			answer, err := part(r)
+			done <- result{answer, err}
+		}()
+		select {
+		case res := <-done:
+			return res.answer, res.err
+		case <-ctx.Done():
+			return Answer{}, ctx.Err()
+		}
+	}, label, nil
+}
+
+var (
+	mu   sync.RWMutex
+	days = make(map[int]Day)
+)
+
+// Register makes a day available to the runner. It panics if the day is
+// registered twice, like database/sql.Register does for drivers.
+func Register(d Day) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, dup := days[d.Number]; dup {
+		panic(fmt.Sprintf("solver: day %d registered twice", d.Number))
+	}
+	days[d.Number] = d
+}
+
+// Lookup returns the registered day with the given number.
+func Lookup(n int) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	d, ok := days[n]
+	return d, ok
+}
+
+// Days returns all registered days sorted by number.
+func Days() []Day {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]Day, 0, len(days))
+	for _, d := range days {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Number < list[j].Number
+	})
+	return list
+}
+
+// Dir returns the directory name that holds a day's files, e.g. "day07".
+func Dir(n int) string {
+	return fmt.Sprintf("day%02d", n)
+}
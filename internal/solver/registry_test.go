@@ -0,0 +1,251 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countLines is a tiny Solver used to exercise the registry.
+type countLines struct{}
+
+func (countLines) Part1(r io.Reader) (Answer, error) {
+	b, err := io.ReadAll(r)
+	return Int(strings.Count(string(b), "\n")), err
+}
+
+func (countLines) Part2(r io.Reader) (Answer, error) {
+	n, err := countLines{}.Part1(r)
+	return n.Add(n), err
+}
+
+func TestDayPart(t *testing.T) {
+	d := Day{
+		Number: 42,
+		Solver: countLines{},
+		Labels: [2]string{"Part 1", "Part 2"},
+	}
+
+	for _, n := range []int{1, 2} {
+		solve, label, err := d.Part(n)
+		if err != nil {
+			t.Fatalf("Part(%d) returned error: %v", n, err)
+		}
+		result, _ := solve(strings.NewReader("a\n"))
+		if !result.Equal(Int(n)) || label != d.Labels[n-1] {
+			t.Errorf("Part(%d) = %d %q, expected %d %q", n, result, label, n, d.Labels[n-1])
+		}
+	}
+
+	if _, _, err := d.Part(3); err == nil {
+		t.Error("Expected error for part 3")
+	}
+}
+
+// blocking never finishes a part on its own.
+type blocking struct{ countLines }
+
+func (blocking) Part1Context(ctx context.Context, r io.Reader) (Answer, error) {
+	<-ctx.Done()
+	return Answer{}, ctx.Err()
+}
+
+func (blocking) Part2Context(ctx context.Context, r io.Reader) (Answer, error) {
+	return Int(-2), nil
+}
+
+func TestDayPartContext(t *testing.T) {
+	plain := Day{Number: 42, Solver: countLines{}}
+	solve, _, err := plain.PartContext(2)
+	if err != nil {
+		t.Fatalf("PartContext(2) returned error: %v", err)
+	}
+	if result, _ := solve(context.Background(), strings.NewReader("a\nb\n")); !result.Equal(Int(4)) {
+		t.Errorf("Expected 4, got %d", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := solve(ctx, strings.NewReader("a\n")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// A plain part that blocks is abandoned when the context ends.
+	stuck := Day{Number: 43, Solver: countLines{}}
+	stuckPart, _, _ := stuck.PartContext(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	blockForever, _ := io.Pipe()
+	if _, err := stuckPart(ctx, blockForever); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	cs := Day{Number: 44, Solver: blocking{}}
+	solve, _, _ = cs.PartContext(2)
+	if result, _ := solve(context.Background(), nil); !result.Equal(Int(-2)) {
+		t.Errorf("Expected the ContextSolver method to be used, got %d", result)
+	}
+	if _, _, err := cs.PartContext(3); err == nil {
+		t.Error("Expected error for part 3")
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	// Without a callback reporting is a no-op.
+	ReportProgress(context.Background(), 1, 2)
+
+	var got [][2]int64
+	ctx := WithProgress(context.Background(), func(done, total int64) {
+		got = append(got, [2]int64{done, total})
+	})
+	ReportProgress(ctx, 1, 4)
+	ReportProgress(ctx, 4, 4)
+	if len(got) != 2 || got[0] != [2]int64{1, 4} || got[1] != [2]int64{4, 4} {
+		t.Errorf("Expected two reports, got %v", got)
+	}
+}
+
+func TestPartialError(t *testing.T) {
+	var err error = &PartialError{Answer: Int(7), Done: 3, Total: 10, Unit: "ranges", Err: context.Canceled}
+	expected := "stopped after 3 of 10 ranges with partial answer 7: context canceled"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+	var partial *PartialError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &partial) || !partial.Answer.Equal(Int(7)) {
+		t.Errorf("Expected a partial error wrapping context.Canceled, got %v", err)
+	}
+}
+
+// scaled multiplies the line count by Factor.
+type scaled struct {
+	countLines
+	Factor int
+}
+
+func (scaled) Params() []Param {
+	return []Param{{Name: "factor", Default: 3, Min: 1, Usage: "multiplier"}, {Name: "limit", Default: 10}}
+}
+
+func (s scaled) WithParams(values Values) (Solver, error) {
+	if values["factor"] > values["limit"] {
+		return nil, errors.New("factor must not exceed limit")
+	}
+	return scaled{Factor: values["factor"]}, nil
+}
+
+func (s scaled) Part1(r io.Reader) (Answer, error) {
+	n, err := s.countLines.Part1(r)
+	return n.Mul(Int(s.Factor)), err
+}
+
+func TestDayConfigure(t *testing.T) {
+	d := Day{Number: 42, Solver: scaled{}}
+	if len(d.Params()) != 2 {
+		t.Fatalf("Expected two parameters, got %v", d.Params())
+	}
+
+	tests := []struct {
+		values   Values
+		expected int
+		err      string
+	}{
+		{nil, 3, ""},
+		{Values{"factor": 5}, 5, ""},
+		{Values{"factor": 0}, 0, "day 42: factor must be at least 1, got 0"},
+		{Values{"size": 5}, 0, `day 42 has no parameter "size" (known: factor, limit)`},
+		{Values{"factor": 11}, 0, "day 42: factor must not exceed limit"},
+	}
+	for _, tt := range tests {
+		configured, err := d.Configure(tt.values)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Configure(%v): expected error %q, got %v", tt.values, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Configure(%v): %v", tt.values, err)
+		}
+		result, _ := configured.Solver.Part1(strings.NewReader("a\n"))
+		if !result.Equal(Int(tt.expected)) {
+			t.Errorf("Configure(%v): expected %d, got %d", tt.values, tt.expected, result)
+		}
+	}
+
+	plain := Day{Number: 43, Solver: countLines{}}
+	if _, err := plain.Configure(nil); err != nil {
+		t.Errorf("Expected no error without values, got %v", err)
+	}
+	if _, err := plain.Configure(Values{"x": 1}); err == nil {
+		t.Error("Expected error for a solver without parameters")
+	}
+}
+
+// useEmptyRegistry gives the test an empty registry and restores the
+// previous one when it ends.
+func useEmptyRegistry(t *testing.T) {
+	mu.Lock()
+	saved := days
+	days = make(map[int]Day)
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		days = saved
+		mu.Unlock()
+	})
+}
+
+func TestRegister(t *testing.T) {
+	useEmptyRegistry(t)
+	Register(Day{Number: 24})
+	Register(Day{Number: 23})
+
+	if _, ok := Lookup(24); !ok {
+		t.Error("Expected day 24 to be registered")
+	}
+	if _, ok := Lookup(25); ok {
+		t.Error("Expected day 25 to be missing")
+	}
+
+	if list := Days(); len(list) != 2 || list[0].Number != 23 || list[1].Number != 24 {
+		t.Errorf("Expected days 23 and 24 in order, got %v", list)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on duplicate registration")
+		}
+	}()
+	Register(Day{Number: 24})
+}
+
+func TestDir(t *testing.T) {
+	if got := Dir(7); got != "day07" {
+		t.Errorf("Dir(7) = %q, expected %q", got, "day07")
+	}
+}
+
+func TestSolveFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(filename, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SolveFile(countLines{}.Part1, filename)
+	if err != nil {
+		t.Fatalf("Error solving: %v", err)
+	}
+	if !result.Equal(Int(3)) {
+		t.Errorf("Expected 3, got %d", result)
+	}
+
+	if _, err := SolveFile(countLines{}.Part1, filename+".missing"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,188 @@
+// Package story extracts the example input and expected answers from a
+// day's story.md, the puzzle text copied from the website.
+//
+// The text is plain prose without markup, so extraction is heuristic: the
+// example is the block of non-prose lines after a line ending in "For
+// example:" (or "the following rotations:"), and the expected answer is the
+// last number of the last sentence that reads like a result ("in this
+// example ... is 3", "produces 1227775554", "... = 357").
+package story
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// Story is the parsed text of one day.
+type Story struct {
+	// Title is the heading, e.g. "Day 2: Gift Shop".
+	Title string
+	Parts [2]Part
+}
+
+// Part is what the story says about one part of the puzzle.
+type Part struct {
+	// Example is the example input, newline terminated. Part two reuses
+	// the example of part one unless it introduces its own.
+	Example string
+	// Expected is the answer the text gives for Example, or nil.
+	Expected *solver.Answer
+	// Accepted is the answer the site accepted ("Your puzzle answer was
+	// N."), or nil while the part is unsolved.
+	Accepted *solver.Answer
+}
+
+const partTwo = "--- Part Two ---"
+
+var (
+	titleRe    = regexp.MustCompile(`^--- (.+) ---$`)
+	markerRe   = regexp.MustCompile(`(?i)(example|the following [a-z ]+):$`)
+	proseRe    = regexp.MustCompile(`[A-Za-z']+ [A-Za-z']+ [A-Za-z']+`)
+	acceptedRe = regexp.MustCompile(`^Your puzzle answer was (\d+)\.`)
+	coordRe    = regexp.MustCompile(`\d+(,\d+)+`)
+	numberRe   = regexp.MustCompile(`\d+`)
+	resultRe   = regexp.MustCompile(`(?i)\bexample\b|\btotal\b|\bproduces\b|=`)
+)
+
+// Load parses the story in filename.
+func Load(filename string) (*Story, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a story. Missing examples or answers are left empty rather
+// than reported, since an unsolved part has no accepted answer yet.
+func Parse(r io.Reader) (*Story, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Story{}
+	for i, line := range lines {
+		if m := titleRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil && m[0] != partTwo {
+			s.Title = m[1]
+			lines = lines[i+1:]
+			break
+		}
+	}
+
+	parts := [2][]string{lines, nil}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == partTwo {
+			parts = [2][]string{lines[:i], lines[i+1:]}
+			break
+		}
+	}
+
+	for i, text := range parts {
+		p := &s.Parts[i]
+		p.Example = example(text)
+		if p.Example == "" && i == 1 {
+			p.Example = s.Parts[0].Example
+		}
+		if p.Expected, err = expected(text); err != nil {
+			return nil, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		for _, line := range text {
+			if m := acceptedRe.FindStringSubmatch(line); m != nil {
+				a, err := solver.Parse(m[1])
+				if err != nil {
+					return nil, fmt.Errorf("part %d: %w", i+1, err)
+				}
+				p.Accepted = &a
+			}
+		}
+	}
+	return s, nil
+}
+
+// example returns the first example block of text. Blank lines inside the
+// block are kept, trailing whitespace is not. A following note that the
+// lines were "wrapped here for legibility" joins them back into one line.
+func example(text []string) string {
+	for i, line := range text {
+		line = strings.TrimSpace(line)
+		if !markerRe.MatchString(line) || strings.Contains(line, "above") {
+			continue
+		}
+
+		var block []string
+		wrapped := false
+		for _, l := range text[i+1:] {
+			l = strings.TrimRight(l, " \t")
+			if proseRe.MatchString(l) {
+				wrapped = strings.Contains(l, "wrapped here for legibility")
+				break
+			}
+			if l == "" && len(block) == 0 {
+				continue
+			}
+			block = append(block, l)
+		}
+		for len(block) > 0 && block[len(block)-1] == "" {
+			block = block[:len(block)-1]
+		}
+		if len(block) == 0 {
+			continue
+		}
+		if wrapped {
+			return strings.Join(block, "") + "\n"
+		}
+		return strings.Join(block, "\n") + "\n"
+	}
+	return ""
+}
+
+// expected returns the last number of the last result sentence before the
+// accepted answer, or nil if there is none. Coordinates such as "2,5" are
+// not answers and are ignored.
+func expected(text []string) (*solver.Answer, error) {
+	var last string
+	for _, line := range text {
+		if acceptedRe.MatchString(line) {
+			break
+		}
+		for _, sentence := range sentences(line) {
+			if strings.HasSuffix(sentence, "?") || !resultRe.MatchString(sentence) {
+				continue
+			}
+			numbers := numberRe.FindAllString(coordRe.ReplaceAllString(sentence, ""), -1)
+			if len(numbers) > 0 {
+				last = numbers[len(numbers)-1]
+			}
+		}
+	}
+	if last == "" {
+		return nil, nil
+	}
+	a, err := solver.Parse(last)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// sentences splits a line after every '.', '!', '?' or ':' that is followed
+// by a space.
+func sentences(line string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(line)-1; i++ {
+		if strings.IndexByte(".!?:", line[i]) >= 0 && line[i+1] == ' ' {
+			out = append(out, strings.TrimSpace(line[start:i+1]))
+			start = i + 1
+		}
+	}
+	return append(out, strings.TrimSpace(line[start:]))
+}
@@ -0,0 +1,108 @@
+package story
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sample = `--- Day 42: Sample ---
+The elves count things (your puzzle input). For example:
+
+1
+2
+
+3
+Every line holds a number. So, in this example, the total is 1 + 2 + 3 = 6.
+
+What is the total?
+
+Your puzzle answer was 1234.
+
+--- Part Two ---
+Now multiply instead. For the points 2,5 and 9,7 the answer changes. In this example, the product produces 6 again. One way is between 2,5 and 11,1:
+
+...
+What is the product?
+`
+
+func TestParse(t *testing.T) {
+	s, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+
+	if s.Title != "Day 42: Sample" {
+		t.Errorf("Expected title %q, got %q", "Day 42: Sample", s.Title)
+	}
+	for i, p := range s.Parts {
+		if p.Example != "1\n2\n\n3\n" {
+			t.Errorf("Part %d: expected example %q, got %q", i+1, "1\n2\n\n3\n", p.Example)
+		}
+		if p.Expected == nil || p.Expected.String() != "6" {
+			t.Errorf("Part %d: expected answer 6, got %v", i+1, p.Expected)
+		}
+	}
+	if s.Parts[0].Accepted == nil || s.Parts[0].Accepted.String() != "1234" {
+		t.Errorf("Expected accepted answer 1234, got %v", s.Parts[0].Accepted)
+	}
+	if s.Parts[1].Accepted != nil {
+		t.Errorf("Expected no accepted answer for part 2, got %v", s.Parts[1].Accepted)
+	}
+}
+
+func TestParseWrappedExample(t *testing.T) {
+	text := `For example:
+
+11-22,95-115,
+998-1012
+(The ID ranges are wrapped here for legibility; in your input, they appear on a single long line.)
+`
+	s, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	if s.Parts[0].Example != "11-22,95-115,998-1012\n" {
+		t.Errorf("Expected joined example, got %q", s.Parts[0].Example)
+	}
+	if s.Parts[0].Expected != nil {
+		t.Errorf("Expected no answer, got %v", s.Parts[0].Expected)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	s, err := Parse(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	if s.Title != "" || s.Parts[0].Example != "" || s.Parts[1].Expected != nil {
+		t.Errorf("Expected empty story, got %+v", s)
+	}
+}
+
+// TestStoriesMatchInputTest makes sure the example extracted from every
+// story.md is the day's input_test.
+func TestStoriesMatchInputTest(t *testing.T) {
+	stories, err := filepath.Glob(filepath.Join("..", "..", "day*", "story.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, filename := range stories {
+		s, err := Load(filename)
+		if err != nil {
+			t.Fatalf("Error loading %s: %v", filename, err)
+		}
+		want, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "input_test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Hand-typed files do not always end in a newline.
+		if s.Parts[0].Example != strings.TrimRight(string(want), "\n")+"\n" {
+			t.Errorf("%s: expected example %q, got %q", filename, want, s.Parts[0].Example)
+		}
+		if s.Parts[0].Expected == nil || s.Parts[1].Expected == nil {
+			t.Errorf("%s: expected example answers for both parts, got %+v", filename, s.Parts)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Package storytest checks a day's solver against the example in its
+// story.md, so tests stay in sync with the puzzle text.
+package storytest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/abtris/aoc2025/internal/solver"
+	"github.com/abtris/aoc2025/internal/story"
+)
+
+// File is the story read by Load, relative to the package under test.
+const File = "story.md"
+
+// Load parses the story of the package under test. A missing story.md is
+// treated as an empty story.
+func Load(tb testing.TB) *story.Story {
+	tb.Helper()
+	s, err := story.Load(File)
+	if errors.Is(err, os.ErrNotExist) {
+		return &story.Story{}
+	}
+	if err != nil {
+		tb.Fatalf("Error loading %s: %v", File, err)
+	}
+	return s
+}
+
+// Check runs both parts of s on the story's example and compares the results
+// with the answers the story gives. Parts without an example or an expected
+// answer are skipped.
+func Check(t *testing.T, s solver.Solver) {
+	t.Helper()
+	st := Load(t)
+	parts := [2]solver.PartFunc{s.Part1, s.Part2}
+	for i, p := range st.Parts {
+		t.Run(fmt.Sprintf("part%d", i+1), func(t *testing.T) {
+			if p.Example == "" || p.Expected == nil {
+				t.Skipf("%s has no example answer for part %d", File, i+1)
+			}
+			result, err := parts[i](strings.NewReader(p.Example))
+			if err != nil {
+				t.Fatalf("Error solving part %d: %v", i+1, err)
+			}
+			if !result.Equal(*p.Expected) {
+				t.Errorf("Part %d: expected %d, got %d", i+1, *p.Expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package timing
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultHistoryFile is where aoc bench keeps its results, relative to the
+// repository root. It is not committed.
+const DefaultHistoryFile = "bench_history.json"
+
+// Bench is the repeated measurement of one part.
+type Bench struct {
+	Day   int      `json:"day"`
+	Part  int      `json:"part"`
+	Input string   `json:"input"`
+	Runs  []Result `json:"runs"`
+}
+
+// NsPerOp returns the time per run of every successful measurement.
+func (b Bench) NsPerOp() []float64 {
+	return b.metric(func(r Result) float64 { return float64(r.NsPerOp) })
+}
+
+// AllocsPerOp returns the allocations per run of every successful
+// measurement.
+func (b Bench) AllocsPerOp() []float64 {
+	return b.metric(func(r Result) float64 { return float64(r.AllocsPerOp) })
+}
+
+func (b Bench) metric(value func(Result) float64) []float64 {
+	var xs []float64
+	for _, r := range b.Runs {
+		if r.Error == "" {
+			xs = append(xs, value(r))
+		}
+	}
+	return xs
+}
+
+// Record is one benchmark session of a commit.
+type Record struct {
+	Commit string `json:"commit"`
+	// Dirty is set when the working tree had uncommitted changes.
+	Dirty   bool      `json:"dirty,omitempty"`
+	Date    time.Time `json:"date"`
+	Benches []Bench   `json:"benches"`
+}
+
+// Bench returns the measurement of a part, if the record has one.
+func (r Record) Bench(day, part int, input string) (Bench, bool) {
+	for _, b := range r.Benches {
+		if b.Day == day && b.Part == part && b.Input == input {
+			return b, true
+		}
+	}
+	return Bench{}, false
+}
+
+// History is every saved record, oldest first.
+type History []Record
+
+// LoadHistory reads filename. A missing file is an empty history.
+func LoadHistory(filename string) (History, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Save writes the history to filename.
+func (h History) Save(filename string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// Add appends r, replacing an earlier record of the same commit.
+func (h *History) Add(r Record) {
+	for i, old := range *h {
+		if old.Commit == r.Commit {
+			*h = append((*h)[:i], (*h)[i+1:]...)
+			break
+		}
+	}
+	*h = append(*h, r)
+}
+
+// Find returns the latest record whose commit starts with ref.
+func (h History) Find(ref string) (Record, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if ref != "" && strings.HasPrefix(h[i].Commit, ref) {
+			return h[i], true
+		}
+	}
+	return Record{}, false
+}
+
+// Latest returns the most recent record of a commit other than commit.
+func (h History) Latest(commit string) (Record, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Commit != commit {
+			return h[i], true
+		}
+	}
+	return Record{}, false
+}
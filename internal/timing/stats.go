@@ -0,0 +1,125 @@
+package timing
+
+import (
+	"math"
+	"slices"
+)
+
+// Alpha is the significance level below which a difference between two
+// sets of runs is treated as real, as in benchstat.
+const Alpha = 0.05
+
+// Summary describes repeated measurements of one metric.
+type Summary struct {
+	N      int
+	Median float64
+	// Variation is the largest distance of a run from the median,
+	// relative to the median, printed as "±x%".
+	Variation float64
+}
+
+// Summarize returns the median and variation of xs.
+func Summarize(xs []float64) Summary {
+	if len(xs) == 0 {
+		return Summary{}
+	}
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+	s := Summary{N: len(sorted)}
+	if mid := len(sorted) / 2; len(sorted)%2 == 1 {
+		s.Median = sorted[mid]
+	} else {
+		s.Median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	if s.Median != 0 {
+		spread := max(s.Median-sorted[0], sorted[len(sorted)-1]-s.Median)
+		s.Variation = spread / s.Median
+	}
+	return s
+}
+
+// Delta is the change of a metric between a base and a new set of runs.
+type Delta struct {
+	Base, New Summary
+	// Change is the relative change of the median, 0.1 for 10% slower.
+	Change float64
+	// P is the two-sided p-value of the Mann-Whitney U test.
+	P float64
+}
+
+// Significant reports whether the difference is unlikely to be noise.
+func (d Delta) Significant() bool {
+	return d.P < Alpha
+}
+
+// Compare summarises base and new and tests whether they differ.
+func Compare(base, new []float64) Delta {
+	d := Delta{Base: Summarize(base), New: Summarize(new), P: MannWhitneyU(base, new)}
+	if d.Base.Median != 0 {
+		d.Change = (d.New.Median - d.Base.Median) / d.Base.Median
+	}
+	return d
+}
+
+// MannWhitneyU returns the two-sided p-value of the Mann-Whitney U test
+// that a and b come from the same distribution. It uses the normal
+// approximation with tie and continuity corrections, which is close enough
+// for the handful of runs a benchmark makes. Identical samples give 1.
+func MannWhitneyU(a, b []float64) float64 {
+	n1, n2 := float64(len(a)), float64(len(b))
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type value struct {
+		x     float64
+		fromA bool
+	}
+	all := make([]value, 0, len(a)+len(b))
+	for _, x := range a {
+		all = append(all, value{x, true})
+	}
+	for _, x := range b {
+		all = append(all, value{x, false})
+	}
+	slices.SortFunc(all, func(p, q value) int {
+		switch {
+		case p.x < q.x:
+			return -1
+		case p.x > q.x:
+			return 1
+		}
+		return 0
+	})
+
+	// Tied values share the average of their ranks.
+	var rankA, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].x == all[i].x {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for _, v := range all[i:j] {
+			if v.fromA {
+				rankA += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	n := n1 + n2
+	u := rankA - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z <= 0 {
+		return 1
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
@@ -0,0 +1,115 @@
+// Package timing measures how long a solver part takes and how much memory
+// it uses.
+package timing
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/input"
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+// DefaultBenchTime matches the default of go test -benchtime.
+const DefaultBenchTime = time.Second
+
+// Result is the cost of one part of one day against one input.
+type Result struct {
+	Day   int    `json:"day"`
+	Part  int    `json:"part"`
+	Input string `json:"input"`
+	// Runs is the number of timed runs the averages are taken over.
+	Runs        int           `json:"runs"`
+	NsPerOp     time.Duration `json:"ns_per_op"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+	// PeakHeap is the highest live heap above the starting point seen
+	// while the part ran once on its own. It is sampled, so very short
+	// spikes can be missed.
+	PeakHeap uint64        `json:"peak_heap_bytes"`
+	Answer   solver.Answer `json:"answer"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// heapMetric is the live heap including objects not yet swept.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// sampleInterval is how often the live heap is read during the peak run.
+const sampleInterval = 100 * time.Microsecond
+
+// Measure runs part on data once to get the answer and the peak heap, then
+// repeatedly until benchTime has passed to average time and allocations.
+// The input is held in memory so reading the file is not measured. An
+// error from the part ends the measurement and is recorded in the result.
+func Measure(part solver.PartFunc, name string, data []byte, benchTime time.Duration) Result {
+	var r Result
+	solve := func() (solver.Answer, error) {
+		return part(&input.Source{R: bytes.NewReader(data), Name: name})
+	}
+
+	answer, peak, err := peakHeap(solve)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Answer, r.PeakHeap = answer, peak
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for r.Runs == 0 || time.Since(start) < benchTime {
+		if _, err := solve(); err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		r.Runs++
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := uint64(r.Runs)
+	r.NsPerOp = elapsed / time.Duration(r.Runs)
+	r.AllocsPerOp = (after.Mallocs - before.Mallocs) / n
+	r.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / n
+	return r
+}
+
+// peakHeap runs solve once while a goroutine samples the live heap.
+func peakHeap(solve func() (solver.Answer, error)) (solver.Answer, uint64, error) {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	read := func() uint64 {
+		metrics.Read(sample)
+		return sample[0].Value.Uint64()
+	}
+
+	runtime.GC()
+	base := read()
+	peak := base
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(sampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				peak = max(peak, read())
+			}
+		}
+	}()
+
+	answer, err := solve()
+	close(done)
+	wg.Wait()
+	peak = max(peak, read())
+	return answer, peak - base, err
+}
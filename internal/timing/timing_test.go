@@ -0,0 +1,149 @@
+package timing
+
+import (
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abtris/aoc2025/internal/solver"
+)
+
+var sink [][]byte
+
+func allocating(r io.Reader) (solver.Answer, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sink = sink[:0]
+	for range 10 {
+		sink = append(sink, make([]byte, 1<<20))
+	}
+	return solver.Int(len(data)), nil
+}
+
+func TestMeasure(t *testing.T) {
+	r := Measure(allocating, "input", []byte("hello"), 10*time.Millisecond)
+	if r.Error != "" {
+		t.Fatalf("Unexpected error: %s", r.Error)
+	}
+	if !r.Answer.Equal(solver.Int(5)) {
+		t.Errorf("Expected answer 5, got %d", r.Answer)
+	}
+	if r.Runs < 1 || r.NsPerOp <= 0 {
+		t.Errorf("Expected timed runs, got %d runs at %v", r.Runs, r.NsPerOp)
+	}
+	if r.AllocsPerOp < 10 {
+		t.Errorf("Expected at least 10 allocs/op, got %d", r.AllocsPerOp)
+	}
+	if r.BytesPerOp < 10<<20 {
+		t.Errorf("Expected at least 10MB/op, got %d", r.BytesPerOp)
+	}
+	if r.PeakHeap < 1<<20 {
+		t.Errorf("Expected a peak heap of at least 1MB, got %d", r.PeakHeap)
+	}
+}
+
+func TestMeasureError(t *testing.T) {
+	failing := func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, errors.New("bad input")
+	}
+	r := Measure(failing, "input", nil, time.Second)
+	if r.Error != "bad input" || r.Runs != 0 {
+		t.Errorf("Expected the error and no runs, got %+v", r)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		xs        []float64
+		median    float64
+		variation float64
+	}{
+		{nil, 0, 0},
+		{[]float64{5}, 5, 0},
+		{[]float64{10, 8, 12}, 10, 0.2},
+		{[]float64{4, 1, 3, 2}, 2.5, 0.6},
+	}
+	for _, tt := range tests {
+		s := Summarize(tt.xs)
+		if s.N != len(tt.xs) || s.Median != tt.median || math.Abs(s.Variation-tt.variation) > 1e-9 {
+			t.Errorf("Summarize(%v) = %+v, expected median %v variation %v", tt.xs, s, tt.median, tt.variation)
+		}
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        []float64
+		significant bool
+	}{
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, true},
+		{"interleaved", []float64{1, 3, 5, 7, 9}, []float64{2, 4, 6, 8, 10}, false},
+		{"identical", []float64{4, 4, 4}, []float64{4, 4, 4}, false},
+		{"constant but different", []float64{4, 4, 4, 4, 4}, []float64{5, 5, 5, 5, 5}, true},
+		{"too few runs", []float64{1}, []float64{2}, false},
+		{"empty", nil, []float64{1, 2}, false},
+	}
+	for _, tt := range tests {
+		p := MannWhitneyU(tt.a, tt.b)
+		if p < 0 || p > 1 {
+			t.Errorf("%s: p-value %v out of range", tt.name, p)
+		}
+		if (p < Alpha) != tt.significant {
+			t.Errorf("%s: expected significant=%v, got p=%v", tt.name, tt.significant, p)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	d := Compare([]float64{100, 101, 99, 100, 100}, []float64{120, 121, 119, 120, 122})
+	if !d.Significant() || math.Abs(d.Change-0.2) > 1e-9 {
+		t.Errorf("Expected a significant +20%%, got %+v", d)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), DefaultHistoryFile)
+	h, err := LoadHistory(filename)
+	if err != nil || len(h) != 0 {
+		t.Fatalf("Expected an empty history, got %v, %v", h, err)
+	}
+
+	bench := Bench{Day: 1, Part: 2, Input: "input", Runs: []Result{{NsPerOp: 10, AllocsPerOp: 3}, {Error: "boom"}}}
+	h.Add(Record{Commit: "aaaa1111", Benches: []Bench{bench}})
+	h.Add(Record{Commit: "bbbb2222"})
+	h.Add(Record{Commit: "aaaa1111", Dirty: true, Benches: []Bench{bench}})
+	if err := h.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err = LoadHistory(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 2 || h[1].Commit != "aaaa1111" || !h[1].Dirty {
+		t.Fatalf("Expected the re-run commit to replace the old record, got %+v", h)
+	}
+	if r, ok := h.Find("bbbb"); !ok || r.Commit != "bbbb2222" {
+		t.Errorf("Expected to find bbbb2222, got %v %v", r.Commit, ok)
+	}
+	if _, ok := h.Find(""); ok {
+		t.Error("Expected an empty ref to match nothing")
+	}
+	if r, ok := h.Latest("aaaa1111"); !ok || r.Commit != "bbbb2222" {
+		t.Errorf("Expected latest other commit bbbb2222, got %v %v", r.Commit, ok)
+	}
+
+	b, ok := h[1].Bench(1, 2, "input")
+	if !ok {
+		t.Fatal("Expected the bench of day 1 part 2")
+	}
+	if ns := b.NsPerOp(); len(ns) != 1 || ns[0] != 10 {
+		t.Errorf("Expected failed runs to be ignored, got %v", ns)
+	}
+}
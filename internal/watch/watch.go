@@ -0,0 +1,144 @@
+// Package watch notices changed files by polling their size and
+// modification time, so it needs no platform specific notifier.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Defaults for Watcher.
+const (
+	DefaultInterval = 500 * time.Millisecond
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// fileState is what a poll compares.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Watcher polls the files in Dir that Match accepts.
+type Watcher struct {
+	Dir   string
+	Match func(name string) bool
+	// Interval is the time between polls.
+	Interval time.Duration
+	// Debounce is how long the files must stay unchanged after a change
+	// before it is reported, so an editor saving several files or
+	// writing one in steps triggers a single run.
+	Debounce time.Duration
+
+	last map[string]fileState
+}
+
+// New returns a watcher with the default interval and debounce.
+func New(dir string, match func(name string) bool) *Watcher {
+	return &Watcher{Dir: dir, Match: match, Interval: DefaultInterval, Debounce: DefaultDebounce}
+}
+
+// Mark records the current state of the files, so the next Wait reports
+// the changes made from now on.
+func (w *Watcher) Mark() error {
+	state, err := w.snapshot()
+	if err != nil {
+		return err
+	}
+	w.last = state
+	return nil
+}
+
+// Wait blocks until the watched files change and then settle, and returns
+// the names of the files that were added, modified or removed since the
+// previous call or Mark. Without either, it first records the current
+// state.
+func (w *Watcher) Wait(ctx context.Context) ([]string, error) {
+	if w.last == nil {
+		if err := w.Mark(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		current  = w.last
+		changed  bool
+		settleAt time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(w.Interval):
+		}
+
+		state, err := w.snapshot()
+		if err != nil {
+			return nil, err
+		}
+		if len(diff(current, state)) > 0 {
+			current = state
+			changed = true
+			settleAt = time.Now().Add(w.Debounce)
+			continue
+		}
+		if changed && !time.Now().Before(settleAt) {
+			names := diff(w.last, current)
+			w.last = current
+			if len(names) > 0 {
+				return names, nil
+			}
+			// The files were changed and then changed back.
+			changed = false
+		}
+	}
+}
+
+// snapshot records the state of every matching file.
+func (w *Watcher) snapshot() (map[string]fileState, error) {
+	entries, err := os.ReadDir(w.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]fileState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := make(map[string]fileState)
+	for _, e := range entries {
+		if e.IsDir() || (w.Match != nil && !w.Match(e.Name())) {
+			continue
+		}
+		info, err := e.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // removed while reading the directory
+		}
+		if err != nil {
+			return nil, err
+		}
+		state[filepath.Join(w.Dir, e.Name())] = fileState{info.Size(), info.ModTime()}
+	}
+	return state, nil
+}
+
+// diff returns the sorted names whose state differs between a and b.
+func diff(a, b map[string]fileState) []string {
+	var names []string
+	for name, s := range b {
+		if old, ok := a[name]; !ok || old != s {
+			names = append(names, name)
+		}
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
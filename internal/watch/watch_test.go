@@ -0,0 +1,95 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	dir := t.TempDir()
+	w := New(dir, func(name string) bool { return strings.HasSuffix(name, ".go") || name == "input" })
+	w.Interval = 5 * time.Millisecond
+	w.Debounce = 20 * time.Millisecond
+	return w, dir
+}
+
+func write(t *testing.T, filename, content string, mod time.Time) {
+	t.Helper()
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Explicit times keep the test independent of the file system's
+	// timestamp resolution.
+	if err := os.Chtimes(filename, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitReportsSettledChanges(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	base := time.Now().Add(-time.Hour)
+	write(t, filepath.Join(dir, "main.go"), "package x", base)
+	write(t, filepath.Join(dir, "notes.txt"), "ignored", base)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type result struct {
+		names []string
+		err   error
+	}
+	results := make(chan result)
+	wait := func() {
+		names, err := w.Wait(ctx)
+		results <- result{names, err}
+	}
+
+	// Record the state before waiting, so the writes below are reported
+	// together once they stop however late the goroutine starts.
+	if err := w.Mark(); err != nil {
+		t.Fatal(err)
+	}
+	go wait()
+	write(t, filepath.Join(dir, "main.go"), "package x // edited", base.Add(time.Minute))
+	write(t, filepath.Join(dir, "input"), "1\n", base)
+	write(t, filepath.Join(dir, "notes.txt"), "still ignored", base.Add(time.Minute))
+
+	r := <-results
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	expected := filepath.Join(dir, "input") + "," + filepath.Join(dir, "main.go")
+	if got := strings.Join(r.names, ","); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	// The previous call left the state it reported as the baseline.
+	go wait()
+	os.Remove(filepath.Join(dir, "input"))
+	if r = <-results; len(r.names) != 1 || filepath.Base(r.names[0]) != "input" {
+		t.Errorf("Expected the removed input, got %v %v", r.names, r.err)
+	}
+}
+
+func TestWaitStopsWithContext(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := w.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	a := map[string]fileState{"a": {1, now}, "b": {2, now}, "c": {3, now}}
+	b := map[string]fileState{"a": {1, now}, "b": {2, now.Add(time.Second)}, "d": {4, now}}
+	if got := strings.Join(diff(a, b), ","); got != "b,c,d" {
+		t.Errorf("Expected b,c,d, got %s", got)
+	}
+}